@@ -36,10 +36,12 @@ func (b *Attractor) ApplyTo(bodies []bodies.Body) { b.targets = bodies }
 func (b *Attractor) Targets() []bodies.Body       { return b.targets }
 func (b *Attractor) SetWorld(world World) {
 	if b.world != nil {
-		world.On("integrate:positions", &b.behaveC)
+		// disconnect
+		b.world.Off("integrate:positions", &b.behaveC)
 	}
 	if world != nil {
-		world.Off("integrate:positions", &b.behaveC)
+		// connect
+		world.On("integrate:positions", &b.behaveC)
 	}
 	b.world = world
 }
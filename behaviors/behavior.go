@@ -18,6 +18,8 @@ type Collision struct {
 	MTV     geom.Vector // the minimum transit vector (the dir and len needed to extract bodyB from bodyA)
 	Pos     geom.Vector // the collision point
 	Overlap float64     // the amount bodyA overlaps bodyB
+	ChildA  int         // the child shape of bodyA that was hit (compound bodies only)
+	ChildB  int         // the child shape of bodyB that was hit (compound bodies only)
 }
 
 type Behavior interface {
@@ -29,6 +31,19 @@ type Behavior interface {
 	SetWorld(world World)
 }
 
+// AllBodies is implemented by behaviors that act on all the bodies
+// of the world until ApplyTo gives them a list. ApplyTo(nil) goes back to all
+type AllBodies interface {
+	AllBodies() bool
+}
+
+// Stateful is implemented by behaviors that keep state between steps,
+// so worlds can save and restore it
+type Stateful interface {
+	SaveState() interface{}
+	RestoreState(state interface{})
+}
+
 /*type behavior struct {
 	targets []bodies.Body
 	world   util.EventTarget
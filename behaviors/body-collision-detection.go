@@ -4,6 +4,8 @@ import (
 	"github.com/oniproject/physics.go/bodies"
 	"github.com/oniproject/physics.go/geom"
 	"github.com/oniproject/physics.go/geometries"
+	"math"
+	"sort"
 )
 
 type BodyCollisionDetection struct {
@@ -32,9 +34,9 @@ func (b *BodyCollisionDetection) SetWorld(world World) {
 	if b.world != nil {
 		// disconnect
 		if b.Check == "forse" || b.Check == "" {
-			world.Off("integrate:velocities", &b.checkAllC)
+			b.world.Off("integrate:velocities", &b.checkAllC)
 		} else {
-			world.Off(b.Check, &b.checkC)
+			b.world.Off(b.Check, &b.checkC)
 		}
 	}
 	if world != nil {
@@ -49,13 +51,19 @@ func (b *BodyCollisionDetection) SetWorld(world World) {
 }
 
 func (b *BodyCollisionDetection) check(candidates map[int]*pair) {
+	// go through the pairs in the order of their hashes, not the random
+	// order of the map, so every run responds to the collisions the same way
+	hashes := make([]int, 0, len(candidates))
+	for hash := range candidates {
+		hashes = append(hashes, hash)
+	}
+	sort.Ints(hashes)
+
 	collisions := []Collision{}
-	for _, pair := range candidates {
+	for _, hash := range hashes {
+		pair := candidates[hash]
 		// TODO check if in b.Targets()
-		ret, ok := b.checkPair(pair.bodyA, pair.bodyB)
-		if ok {
-			collisions = append(collisions, ret)
-		}
+		collisions = append(collisions, CheckBodies(pair.bodyA, pair.bodyB)...)
 	}
 	if len(collisions) > 0 {
 		b.world.Emit(b.Channel, collisions)
@@ -67,10 +75,7 @@ func (b *BodyCollisionDetection) checkAll(candidates map[int]*pair) {
 	for j, bodyA := range targets {
 		for i := j + 1; i < len(targets); i++ {
 			bodyB := targets[i]
-			ret, ok := b.checkPair(bodyA, bodyB)
-			if ok {
-				collisions = append(collisions, ret)
-			}
+			collisions = append(collisions, CheckBodies(bodyA, bodyB)...)
 		}
 	}
 	if len(collisions) > 0 {
@@ -78,26 +83,208 @@ func (b *BodyCollisionDetection) checkAll(candidates map[int]*pair) {
 	}
 }
 
-func (b *BodyCollisionDetection) checkPair(bodyA, bodyB bodies.Body) (c Collision, ok bool) {
+// CheckBodies returns a collision for every pair of child shapes that touch.
+// Bodies that aren't dynamic are never checked against each other
+func CheckBodies(bodyA, bodyB bodies.Body) (collisions []Collision) {
 	// filter out bodies that dont collide with each other
 	if bodyA.Treatment() != bodies.TREATMENT_DYNAMIC &&
-		bodyA.Treatment() != bodies.TREATMENT_DYNAMIC {
-		return c, false
+		bodyB.Treatment() != bodies.TREATMENT_DYNAMIC {
+		return nil
+	}
+
+	aabbA := bodyA.AABB(bodyA.State().Angular.Pos)
+	aabbB := bodyB.AABB(bodyB.State().Angular.Pos)
+
+	for _, sA := range bodyShapes(bodyA, aabbB) {
+		for _, sB := range bodyShapes(bodyB, aabbA) {
+			if !geom.AABBoverlap(sA.aabb, sB.aabb) {
+				continue
+			}
+
+			c, ok := checkShapes(sA, sB)
+			if !ok {
+				continue
+			}
+
+			c.BodyA, c.BodyB = bodyA, bodyB
+			c.ChildA, c.ChildB = sA.index, sB.index
+			// the collision point is relative to the center of bodyA
+			c.Pos = c.Pos.Plus(sA.trans.Vect).Minus(bodyA.State().Pos)
+			collisions = append(collisions, c)
+		}
+	}
+	return
+}
+
+// shape is a convex part of a body placed in the world
+type shape struct {
+	index    int // the child index for compound bodies
+	geometry geometries.Geometry
+	trans    *geom.Transform
+	aabb     geom.AABB
+}
+
+func newShape(index int, geometry geometries.Geometry, pos geom.Vector, angle float64) shape {
+	aabb := geometry.AABB(angle)
+	aabb.X += pos.X
+	aabb.Y += pos.Y
+	return shape{
+		index:    index,
+		geometry: geometry,
+		trans:    geom.NewTransform(pos, angle, geom.Vector{}),
+		aabb:     aabb,
+	}
+}
+
+// bodyShapes returns the parts of the body,
+// for fields only the ones that may touch the box (world coordinates)
+func bodyShapes(body bodies.Body, near geom.AABB) (shapes []shape) {
+	pos, angle := body.State().Pos, body.State().Angular.Pos
+
+	if field, ok := body.Geometry().(geometries.Field); ok {
+		for _, i := range field.Overlapping(localAABB(near, pos, angle)) {
+			part := field.Part(i)
+			childPos, childAngle := geometries.ChildTransform(part, pos, angle)
+			shapes = append(shapes, newShape(i, part.Geometry, childPos, childAngle))
+		}
+		return
 	}
 
-	gA, isA := bodyA.Geometry().(*geometries.Circle)
-	gB, isB := bodyB.Geometry().(*geometries.Circle)
+	container, ok := body.Geometry().(geometries.Container)
+	if !ok {
+		return []shape{newShape(0, body.Geometry(), pos, angle)}
+	}
+
+	for i, child := range container.Parts() {
+		childPos, childAngle := geometries.ChildTransform(child, pos, angle)
+		shapes = append(shapes, newShape(i, child.Geometry, childPos, childAngle))
+	}
+	return
+}
+
+// localAABB returns the box around a world box
+// in the coordinates of a body at pos rotated by angle
+func localAABB(box geom.AABB, pos geom.Vector, angle float64) geom.AABB {
+	trans := geom.NewTransformAngle(angle)
+	min := geom.Vector{math.Inf(1), math.Inf(1)}
+	max := geom.Vector{math.Inf(-1), math.Inf(-1)}
+	for _, c := range []geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+		v := geom.Vector{box.X + c.X*box.HW, box.Y + c.Y*box.HH}
+		v = trans.RotateInv(v.Minus(pos))
+		min.X, min.Y = math.Min(min.X, v.X), math.Min(min.Y, v.Y)
+		max.X, max.Y = math.Max(max.X, v.X), math.Max(max.Y, v.Y)
+	}
+	return geom.NewAABB_byMM(min.X, min.Y, max.X, max.Y)
+}
+
+// checkShapes returns the collision point relative to the center of sA
+func checkShapes(sA, sB shape) (c Collision, ok bool) {
+	gA, isA := sA.geometry.(*geometries.Circle)
+	gB, isB := sB.geometry.(*geometries.Circle)
 	if isA && isB {
-		return checkCircles(bodyA, bodyB, gA, gB)
-		// TODO
-		//} else {
-		//return checkGJK(bodyA, bodyB)
+		return checkCircles(sA.trans.Vect, sB.trans.Vect, gA, gB)
+	}
+
+	c, ok = checkGJK(sA, sB)
+	if !ok {
+		return
+	}
+
+	// segments may only be hit from the front,
+	// and not on the vertices inside a chain
+	if seg, is := sA.geometry.(*geometries.Segment); is {
+		contact := c.Pos.Plus(sA.trans.Vect)
+		c.Norm, c.Overlap, ok = checkSegment(seg, sA, sB, c.Norm, c.Overlap, contact)
+	} else if seg, is := sB.geometry.(*geometries.Segment); is {
+		contact := c.Pos.Plus(sA.trans.Vect).Minus(c.Norm.Times(c.Overlap))
+		var norm geom.Vector
+		norm, c.Overlap, ok = checkSegment(seg, sB, sA, c.Norm.Times(-1), c.Overlap, contact)
+		c.Norm = norm.Times(-1)
+		// keep the contact on the surface of A
+		c.Pos = contact.Plus(c.Norm.Times(c.Overlap)).Minus(sA.trans.Vect)
+	}
+	c.MTV = c.Norm.Times(c.Overlap)
+	return
+}
+
+// checkSegment fixes up the collision of the segment with the other shape.
+// norm points from the segment to the other shape and contact is on the segment.
+//
+// The normal is snapped to the face of the segment unless the contact is
+// on an outer corner of the chain (or its end) and the normal points
+// between the faces there. These are the ghost vertices.
+func checkSegment(seg *geometries.Segment, sSeg, sOther shape, norm geom.Vector, depth float64, contact geom.Vector) (geom.Vector, float64, bool) {
+	face := sSeg.trans.Rotate(seg.Normal())
+	a := sSeg.trans.Translate(sSeg.trans.Rotate(seg.A))
+	b := sSeg.trans.Translate(sSeg.trans.Rotate(seg.B))
+
+	// which side are we on?
+	side := 1.0
+	if geom.DotProduct(sOther.trans.Vect.Minus(a), face) < 0 {
+		if seg.OneSided {
+			// behind a one-sided segment
+			return norm, depth, false
+		}
+		side = -1
+		face = face.Times(-1)
+	}
+
+	// where is the contact along the segment?
+	e := b.Minus(a)
+	t := geom.DotProduct(contact.Minus(a), e) / e.MagnitudeSquared()
+
+	// the ghost vertex and the edge to it at the end that was hit
+	var ghost *geom.Vector
+	var edge geom.Vector
+	switch {
+	case t <= 0.0001:
+		ghost = seg.Prev
+		if ghost != nil {
+			edge = a.Minus(sSeg.trans.Translate(sSeg.trans.Rotate(*ghost)))
+		}
+	case t >= 0.9999:
+		ghost = seg.Next
+		if ghost != nil {
+			edge = sSeg.trans.Translate(sSeg.trans.Rotate(*ghost)).Minus(b)
+		}
+	default:
+		// in the middle, it's a face contact
+		return snapToFace(sSeg, sOther, face, a)
 	}
-	return c, false
+
+	if ghost == nil {
+		// the end of the chain is round
+		return norm, depth, true
+	}
+
+	neighbour := edge.Perp(true).Unit().Times(side)
+	turn := geom.CrossProduct(face, neighbour)
+
+	// the corner between the faces
+	convex := (t <= 0.0001 && geom.CrossProduct(edge, e)*side > 0) ||
+		(t >= 0.9999 && geom.CrossProduct(e, edge)*side > 0)
+	if !convex {
+		return snapToFace(sSeg, sOther, face, a)
+	}
+
+	// the normal has to be between the face and the neighbour's face
+	if geom.CrossProduct(face, norm)*turn < 0 || geom.CrossProduct(norm, neighbour)*turn < 0 {
+		return snapToFace(sSeg, sOther, face, a)
+	}
+
+	return norm, depth, true
+}
+
+// snapToFace measures how deep the other shape is along the face normal
+func snapToFace(sSeg, sOther shape, face, pt geom.Vector) (geom.Vector, float64, bool) {
+	deepest := sOther.geometry.FarthestHullPoint(sOther.trans.RotateInv(face.Times(-1)))
+	deepest = sOther.trans.Translate(sOther.trans.Rotate(deepest))
+	depth := geom.DotProduct(pt.Minus(deepest), face)
+	return face, depth, depth > 0
 }
 
-func checkCircles(bodyA, bodyB bodies.Body, gA, gB *geometries.Circle) (c Collision, ok bool) {
-	d := bodyB.State().Pos.Minus(bodyA.State().Pos)
+func checkCircles(posA, posB geom.Vector, gA, gB *geometries.Circle) (c Collision, ok bool) {
+	d := posB.Minus(posA)
 	overlap := d.Magnitude() - (gA.Radius + gB.Radius)
 
 	// hmm... they overlap exactly... choose a direction
@@ -115,8 +302,6 @@ func checkCircles(bodyA, bodyB bodies.Body, gA, gB *geometries.Circle) (c Collis
 		d = d.Unit()
 		ok = true
 		c = Collision{
-			BodyA:   bodyA,
-			BodyB:   bodyB,
 			Norm:    d,
 			MTV:     d.Times(-overlap),
 			Pos:     d.Times(gA.Radius),
@@ -127,67 +312,82 @@ func checkCircles(bodyA, bodyB bodies.Body, gA, gB *geometries.Circle) (c Collis
 	return
 }
 
-func checkGJK(bodyA, bodyB bodies.Body) {
-	// TODO
-}
-
-type fnT struct {
-	bodyA, bodyB     bodies.Body
-	tA, tB           geom.Transform
-	marginA, marginB float64
-	useCore          bool
-	fn               func(geom.Vector) geom.VectorABP
-}
-
-var supportFnStack = map[int]*fnT{}
-
-func getSupportFnStack(bodyA, bodyB bodies.Body) *fnT {
-	hash := pairHash(int(bodyA.UID()), int(bodyB.UID()))
-	if hash == -1 {
-		panic("fail hash")
+// supportFn returns the support function of the Minkowski Difference sA - sB.
+// with margins, the shapes are shrunk to their cores
+func supportFn(sA, sB shape, marginA, marginB float64) func(geom.Vector) geom.VectorABP {
+	farthest := func(s shape, margin float64, dir geom.Vector) geom.Vector {
+		dir = s.trans.RotateInv(dir)
+		var v geom.Vector
+		if margin > 0 {
+			v = s.geometry.FarthestCorePoint(dir, margin)
+		} else {
+			v = s.geometry.FarthestHullPoint(dir)
+		}
+		return s.trans.Translate(s.trans.Rotate(v))
 	}
-	fn := supportFnStack[hash]
 
-	if fn == nil {
-		fn = &fnT{useCore: false}
-		supportFnStack[hash] = fn
-		fn.fn = func(dir geom.Vector) geom.VectorABP {
-			var vA, vB geom.Vector
-			var mA, mB float64
-			if fn.useCore {
-				mA, mB = fn.marginA, fn.marginB
-			}
+	return func(dir geom.Vector) geom.VectorABP {
+		vA := farthest(sA, marginA, dir)
+		vB := farthest(sB, marginB, dir.Times(-1))
 
-			vA = bodyA.Geometry().FarthestCorePoint(fn.tA.RotateInv(dir), mA)
-			vA = fn.tA.Translate(vA)
-			vA = fn.tA.Rotate(vA)
+		return geom.VectorABP{
+			A:  vA,
+			B:  vB,
+			PT: vA.Minus(vB),
+		}
+	}
+}
 
-			v := fn.tA.Rotate(dir)
-			v = fn.tB.RotateInv(dir)
-			vB = bodyA.Geometry().FarthestCorePoint(v.Times(-1), mB)
-			vB = fn.tB.Translate(vB)
-			vB = fn.tB.Rotate(vB)
+func margin(g geometries.Geometry) float64 {
+	if rounded, ok := g.(geometries.Rounded); ok {
+		return rounded.Margin()
+	}
+	return 0
+}
 
-			// WTF?  fn.Rotate(dir.Times(-1))
+func checkGJK(sA, sB shape) (c Collision, ok bool) {
+	dir := sB.trans.Vect.Minus(sA.trans.Vect)
 
-			return geom.VectorABP{
-				A:  vA,
-				B:  vB,
-				PT: vA.Minus(vB),
+	// rounded shapes: find the distance between the cores
+	// and compare it with the margins
+	marginA, marginB := margin(sA.geometry), margin(sB.geometry)
+	if marginA+marginB > 0 {
+		result := geom.GJK(supportFn(sA, sB, marginA, marginB), dir, false)
+		if !result.Overlap && result.Distance > 0 {
+			if result.Distance >= marginA+marginB {
+				return c, false
 			}
+
+			norm := result.B.Minus(result.A).Unit()
+			overlap := marginA + marginB - result.Distance
+			return Collision{
+				Norm:    norm,
+				MTV:     norm.Times(overlap),
+				Pos:     result.A.Plus(norm.Times(marginA)).Minus(sA.trans.Vect),
+				Overlap: overlap,
+			}, true
 		}
+		// the cores overlap too...
+		// it's deep, so use the whole shapes
 	}
 
-	fn.useCore = false
-	//fn.margin = 0
-	fn.tA.SetTranslation(bodyA.State().Pos)
-	fn.tA.SetRotation(bodyA.State().Angular.Pos, geom.Vector{})
+	support := supportFn(sA, sB, 0, 0)
 
-	fn.tB.SetTranslation(bodyB.State().Pos)
-	fn.tB.SetRotation(bodyB.State().Angular.Pos, geom.Vector{})
+	result := geom.GJK(support, dir, true)
+	if !result.Overlap {
+		return c, false
+	}
 
-	fn.bodyA = bodyA
-	fn.bodyB = bodyB
+	// how deep is it?
+	epa := geom.EPA(support, result.Simplex)
+	if epa.Depth <= 0 {
+		return c, false
+	}
 
-	return fn
+	return Collision{
+		Norm:    epa.Norm,
+		MTV:     epa.Norm.Times(epa.Depth),
+		Pos:     epa.A.Minus(sA.trans.Vect),
+		Overlap: epa.Depth,
+	}, true
 }
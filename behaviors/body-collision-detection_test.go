@@ -0,0 +1,130 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_BodyCollisionDetection(t *testing.T) {
+	Convey("BodyCollisionDetection", t, func() {
+		Convey("should collide a circle with a polygon", func() {
+			circle := bodies.NewCircle(1)
+			square := bodies.NewConvexPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}})
+			square.SetPosition(1.5, 0)
+
+			collisions := CheckBodies(circle, square)
+			So(collisions, ShouldHaveLength, 1)
+			So(collisions[0].Overlap, ShouldAlmostEqual, 0.5, 1e-3)
+			So(collisions[0].Norm.X, ShouldAlmostEqual, 1, 1e-3)
+			So(collisions[0].Pos.X, ShouldAlmostEqual, 1, 1e-3)
+		})
+
+		Convey("should collide capsules with other shapes", func() {
+			capsule := bodies.NewCapsule(2, 1)
+			box := bodies.NewRectangle(2, 2)
+			box.SetPosition(0, 1.5)
+
+			collisions := CheckBodies(capsule, box)
+			So(collisions, ShouldHaveLength, 1)
+			So(collisions[0].Overlap, ShouldAlmostEqual, 0.5, 1e-3)
+			So(collisions[0].Norm.Y, ShouldAlmostEqual, 1, 1e-3)
+
+			other := bodies.NewCapsule(2, 1)
+			other.SetPosition(4.5, 0)
+			collisions = CheckBodies(capsule, other)
+			So(collisions, ShouldHaveLength, 1)
+			So(collisions[0].Overlap, ShouldAlmostEqual, 1.5, 1e-3)
+		})
+
+		Convey("should not catch on the vertices inside a chain", func() {
+			floor := bodies.NewChain([]geom.Vector{{0, 0}, {2, 0}, {4, 0}}, false)
+
+			// the corner of the box just slid over the vertex in the middle
+			box := bodies.NewRectangle(1, 1)
+			box.SetPosition(1.55, -0.4)
+
+			collisions := CheckBodies(box, floor)
+			So(collisions, ShouldHaveLength, 2)
+			for _, c := range collisions {
+				So(c.Norm.X, ShouldAlmostEqual, 0)
+				So(c.Norm.Y, ShouldAlmostEqual, 1)
+				So(c.Overlap, ShouldAlmostEqual, 0.1)
+			}
+		})
+
+		Convey("should only collide with the front of one-sided segments", func() {
+			floor := bodies.NewChain([]geom.Vector{{0, 0}, {4, 0}}, false)
+			floor.SetTreatment(bodies.TREATMENT_STATIC)
+
+			ball := bodies.NewCircle(1)
+			ball.SetPosition(2, 0.5)
+			So(CheckBodies(floor, ball), ShouldBeEmpty)
+
+			ball.SetPosition(2, -0.5)
+			collisions := CheckBodies(floor, ball)
+			So(collisions, ShouldHaveLength, 1)
+			So(collisions[0].Norm.Y, ShouldAlmostEqual, -1, 1e-6)
+			So(collisions[0].Overlap, ShouldAlmostEqual, 0.5, 1e-6)
+		})
+
+		Convey("should use the margins of rounded polygons", func() {
+			a := bodies.NewRoundedPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}, 0.5)
+			b2 := bodies.NewRoundedPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}, 0.5)
+			b2.SetPosition(2.8, 0.5)
+
+			collisions := CheckBodies(a, b2)
+			So(collisions, ShouldHaveLength, 1)
+			So(collisions[0].Overlap, ShouldAlmostEqual, 0.2, 1e-6)
+			So(collisions[0].Norm.X, ShouldAlmostEqual, 1, 1e-6)
+			So(collisions[0].Pos.X, ShouldAlmostEqual, 1.5, 1e-6)
+
+			b2.SetPosition(3.1, 0)
+			So(CheckBodies(a, b2), ShouldBeEmpty)
+		})
+
+		Convey("should say which child of a compound was hit", func() {
+			compound := bodies.NewCompound([]geometries.Child{
+				{Geometry: geometries.NewCircle(1), Pos: geom.Vector{-3, 0}},
+				{Geometry: geometries.NewCircle(1), Pos: geom.Vector{3, 0}},
+			})
+			circle := bodies.NewCircle(1)
+			circle.SetPosition(4.5, 0)
+
+			collisions := CheckBodies(compound, circle)
+			So(collisions, ShouldHaveLength, 1)
+			So(collisions[0].ChildA, ShouldEqual, 1)
+			So(collisions[0].ChildB, ShouldEqual, 0)
+			So(collisions[0].Pos, ShouldResemble, geom.Vector{4, 0})
+		})
+
+		Convey("should collide with the columns of a heightfield", func() {
+			heights := make([]float64, 1000)
+			ground := bodies.NewHeightfield(heights, 1, 1)
+			ground.SetPosition(-500, 0)
+
+			ball := bodies.NewCircle(1)
+			ball.SetPosition(10.5, -0.75)
+			collisions := CheckBodies(ground, ball)
+			So(len(collisions), ShouldBeGreaterThan, 0)
+			So(len(collisions), ShouldBeLessThan, 4)
+			for _, c := range collisions {
+				So(c.Norm.Y, ShouldAlmostEqual, -1, 1e-6)
+				So(c.Overlap, ShouldAlmostEqual, 0.25, 1e-6)
+				So(c.ChildA, ShouldBeGreaterThanOrEqualTo, 509)
+				So(c.ChildA, ShouldBeLessThanOrEqualTo, 511)
+			}
+
+			box := bodies.NewRectangle(2, 2)
+			box.SetPosition(-20, -0.9)
+			collisions = CheckBodies(box, ground)
+			So(len(collisions), ShouldBeGreaterThan, 0)
+			for _, c := range collisions {
+				So(c.Norm.Y, ShouldAlmostEqual, 1, 1e-6)
+				So(c.Overlap, ShouldAlmostEqual, 0.1, 1e-6)
+			}
+		})
+	})
+}
@@ -28,7 +28,7 @@ func (b *BodyImpulseResponse) Targets() []bodies.Body       { return b.targets }
 func (b *BodyImpulseResponse) SetWorld(world World) {
 	if b.world != nil {
 		// disconnect
-		world.Off(b.Channel, &b.respondC)
+		b.world.Off(b.Channel, &b.respondC)
 	}
 	if world != nil {
 		// connect
@@ -45,8 +45,19 @@ func (b *BodyImpulseResponse) respond(collisions []Collision) {
 }
 
 func (b *BodyImpulseResponse) collideBodies(bodyA, bodyB bodies.Body, norm, point, mtv geom.Vector, contact bool) {
-	fixedA := bodyA.Treatment() != bodies.TREATMENT_DYNAMIC
-	fixedB := bodyB.Treatment() != bodies.TREATMENT_DYNAMIC
+	// static and kinematic bodies are never moved by contacts.
+	// a kinematic body still has a velocity, which goes into the relative
+	// velocity below, so it pushes and carries the bodies it touches
+	//
+	// a moving body wakes a sleeping one, otherwise sleeping bodies are fixed too
+	if bodyA.Asleep() && isMoving(bodyB) {
+		bodyA.Wake()
+	}
+	if bodyB.Asleep() && isMoving(bodyA) {
+		bodyB.Wake()
+	}
+	fixedA := bodyA.Treatment() != bodies.TREATMENT_DYNAMIC || bodyA.Asleep()
+	fixedB := bodyB.Treatment() != bodies.TREATMENT_DYNAMIC || bodyB.Asleep()
 
 	// do nothing if both are fixed
 	if fixedA && fixedB {
@@ -91,16 +102,17 @@ func (b *BodyImpulseResponse) collideBodies(bodyA, bodyB bodies.Body, norm, poin
 		cor = bodyA.Restitution() * bodyB.Restitution()
 	}
 
-	// coefficient of friction between bodies
+	// coefficients of kinetic and static friction between bodies
 	cof := bodyA.Cof() * bodyB.Cof()
+	scof := bodyA.StaticCof() * bodyB.StaticCof()
 
 	// vector perpendicular to n
 	perp := norm.Perp(false)
 
-	// collision point from A's center
-	rA := point
-	// collision point from B's center
-	rB := point.Plus(stateA.Pos).Minus(stateB.Pos)
+	// collision point from A's center of mass
+	rA := point.Minus(centerOfMass(bodyA))
+	// collision point from B's center of mass
+	rB := point.Plus(stateA.Pos).Minus(stateB.Pos).Minus(centerOfMass(bodyB))
 
 	//tmp
 
@@ -144,25 +156,22 @@ func (b *BodyImpulseResponse) collideBodies(bodyA, bodyB bodies.Body, norm, poin
 		stateA.Angular.Vel += impulse * invMoiA * rAreg
 	}
 
-	//inContact := (impulse < 0.004)
-	inContact := false
-
 	// if we have friction and a relative velocity perpendicular to the normal
-	if cof != 0 && vreg != 0 {
-		// TODO: here, we could first assume static friction applies
-		// and that the tangential relative velocity is zero.
-		// Then we could calculate the impulse and check if the
-		// tangential impulse is less than that allowed by static
-		// friction. If not, _then_ apply kinetic friction.
-
-		// instead we're just applying kinetic friction and making
-		// sure the impulse we apply is less than the maximum
-		// allowed amount
-
-		// maximum impulse allowed by kinetic friction
+	if (cof != 0 || scof != 0) && vreg != 0 {
+		// first assume static friction applies and that the
+		// tangential relative velocity becomes zero.
+		// this is the impulse needed to stop the sliding
 		max := vreg / (invMassA + invMassB + invMoiA*rAproj*rAproj + invMoiB*rBproj*rBproj)
 
-		if !inContact {
+		if math.Abs(max) <= scof*impulse {
+			// the tangential impulse is within the static limit,
+			// so the bodies stick
+			impulse = max
+		} else {
+			// static friction can't hold them...
+			// apply kinetic friction instead and make sure the
+			// impulse we apply is less than the maximum allowed amount
+
 			// the sign of vreg (plus or minus 1)
 			sign := 1
 			if vreg < 0 {
@@ -177,8 +186,6 @@ func (b *BodyImpulseResponse) collideBodies(bodyA, bodyB bodies.Body, norm, poin
 			} else {
 				impulse = math.Max(impulse, max)
 			}
-		} else {
-			impulse = max
 		}
 
 		// apply frictional impulse
@@ -201,3 +208,17 @@ func (b *BodyImpulseResponse) collideBodies(bodyA, bodyB bodies.Body, norm, poin
 		}
 	}
 }
+
+func isMoving(body bodies.Body) bool {
+	return body.Treatment() != bodies.TREATMENT_STATIC && !body.Asleep()
+}
+
+// centerOfMass returns the rotated centroid of the body,
+// relative to its position
+func centerOfMass(body bodies.Body) geom.Vector {
+	c := body.Centroid()
+	if c.Equals(geom.Vector{}) {
+		return c
+	}
+	return geom.NewTransformAngle(body.State().Angular.Pos).Rotate(c)
+}
@@ -0,0 +1,92 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_BodyImpulseResponse(t *testing.T) {
+	Convey("BodyImpulseResponse friction", t, func() {
+		b := NewBodyImpulseResponse().(*BodyImpulseResponse)
+
+		ball := bodies.NewCircle(10)
+		ball.SetRestitution(0)
+		ball.SetVelocity(1, 5)
+
+		floor := bodies.NewPoint()
+		floor.SetTreatment(bodies.TREATMENT_STATIC)
+		floor.SetPosition(0, 10)
+
+		norm := geom.Vector{0, 1}
+		point := geom.Vector{0, 10}
+
+		// tangential velocity of the ball at the contact point
+		slip := func() float64 {
+			state := ball.State()
+			v := state.Vel.Plus(point.Perp(false).Times(state.Angular.Vel))
+			return v.X
+		}
+
+		Convey("should stick within the static limit", func() {
+			b.collideBodies(ball, floor, norm, point, geom.Vector{}, false)
+			So(slip(), ShouldAlmostEqual, 0)
+		})
+
+		Convey("should slide with kinetic friction above the static limit", func() {
+			ball.SetCof(0.01)
+			b.collideBodies(ball, floor, norm, point, geom.Vector{}, false)
+			So(slip(), ShouldBeGreaterThan, 0)
+			So(slip(), ShouldBeLessThan, 1)
+		})
+
+		Convey("should not clamp static friction below kinetic", func() {
+			ball.SetStaticCof(0.01)
+			So(ball.StaticCof(), ShouldEqual, ball.Cof())
+		})
+
+		Convey("should slide freely without friction", func() {
+			ball.SetCof(0)
+			b.collideBodies(ball, floor, norm, point, geom.Vector{}, false)
+			So(slip(), ShouldEqual, 1)
+		})
+	})
+}
+
+func Test_BodyImpulseResponseKinematic(t *testing.T) {
+	Convey("BodyImpulseResponse kinematic bodies", t, func() {
+		b := NewBodyImpulseResponse().(*BodyImpulseResponse)
+
+		box := bodies.NewCircle(10)
+		box.SetRestitution(0)
+		box.SetStaticCof(1)
+
+		platform := bodies.NewPoint()
+		platform.SetTreatment(bodies.TREATMENT_KINEMATIC)
+		platform.SetPosition(0, 10)
+		platform.SetVelocity(3, -1)
+		platform.SetStaticCof(1)
+
+		norm := geom.Vector{0, 1}
+		point := geom.Vector{0, 10}
+
+		b.collideBodies(box, platform, norm, point, geom.Vector{0, 1}, false)
+
+		Convey("should not be moved by the contact", func() {
+			So(platform.State().Vel, ShouldResemble, geom.Vector{3, -1})
+			So(platform.State().Pos, ShouldResemble, geom.Vector{0, 10})
+		})
+
+		Convey("should push the dynamic body", func() {
+			So(box.State().Vel.Y, ShouldAlmostEqual, -1)
+			So(box.State().Pos, ShouldResemble, geom.Vector{0, -1})
+		})
+
+		Convey("should carry the dynamic body with its surface", func() {
+			state := box.State()
+			v := state.Vel.Plus(point.Perp(false).Times(state.Angular.Vel))
+			So(v.X, ShouldAlmostEqual, 3)
+		})
+	})
+}
@@ -11,8 +11,9 @@ type ConstantAcceleration struct {
 
 	behaveC func(interface{})
 
-	targets []bodies.Body
-	world   World
+	targets    []bodies.Body
+	targetsSet bool
+	world      World
 }
 
 func NewConstantAcceleration(x, y float64) Behavior {
@@ -29,18 +30,21 @@ func (b *ConstantAcceleration) behave() {
 }
 
 func (b *ConstantAcceleration) ApplyTo(bodies []bodies.Body) {
-	b.targets = bodies
+	b.targets, b.targetsSet = bodies, bodies != nil
 }
+
+// Targets returns all the bodies of the world until ApplyTo is given a list
 func (b *ConstantAcceleration) Targets() []bodies.Body {
-	if len(b.targets) == 0 {
+	if !b.targetsSet && b.world != nil {
 		return b.world.Bodies()
 	}
 	return b.targets
 }
+func (b *ConstantAcceleration) AllBodies() bool { return !b.targetsSet }
 func (b *ConstantAcceleration) SetWorld(world World) {
 	if b.world != nil {
 		// disconnect
-		world.Off("integrate:positions", &b.behaveC)
+		b.world.Off("integrate:positions", &b.behaveC)
 	}
 	if world != nil {
 		// connect
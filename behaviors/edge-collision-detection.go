@@ -14,9 +14,10 @@ type EdgeCollisionDetecton struct {
 
 	body bodies.Body
 
-	targets   []bodies.Body
-	checkAllC func(interface{})
-	world     World
+	targets    []bodies.Body
+	targetsSet bool
+	checkAllC  func(interface{})
+	world      World
 }
 
 func NewEdgeCollisionDetection(edges geom.AABB, cof, restitution float64) Behavior {
@@ -34,17 +35,22 @@ func NewEdgeCollisionDetection(edges geom.AABB, cof, restitution float64) Behavi
 	return b
 }
 
-func (b *EdgeCollisionDetecton) ApplyTo(bodies []bodies.Body) { b.targets = bodies }
+func (b *EdgeCollisionDetecton) ApplyTo(bodies []bodies.Body) {
+	b.targets, b.targetsSet = bodies, bodies != nil
+}
+
+// Targets returns all the bodies of the world until ApplyTo is given a list
 func (b *EdgeCollisionDetecton) Targets() []bodies.Body {
-	if len(b.targets) == 0 {
+	if !b.targetsSet && b.world != nil {
 		return b.world.Bodies()
 	}
 	return b.targets
 }
+func (b *EdgeCollisionDetecton) AllBodies() bool { return !b.targetsSet }
 func (b *EdgeCollisionDetecton) SetWorld(world World) {
 	if b.world != nil {
 		// disconnect
-		world.Off("integrate:velocities", &b.checkAllC)
+		b.world.Off("integrate:velocities", &b.checkAllC)
 	}
 	if world != nil {
 		// connect
@@ -64,6 +70,16 @@ func (this *EdgeCollisionDetecton) SetAABB(aabb geom.AABB) {
 	}
 }
 
+// Bounds returns the corners of the edges
+func (this *EdgeCollisionDetecton) Bounds() (min, max geom.Vector) { return this.min, this.max }
+func (this *EdgeCollisionDetecton) SetBounds(min, max geom.Vector) {
+	this.min = min
+	this.max = max
+}
+
+func (this *EdgeCollisionDetecton) Cof() float64         { return this.cof }
+func (this *EdgeCollisionDetecton) Restitution() float64 { return this.restitution }
+
 func (this *EdgeCollisionDetecton) checkAll() {
 	collisions := []Collision{}
 	for _, body := range this.Targets() {
@@ -0,0 +1,91 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+)
+
+// FixedBodyCollisionDetection finds the collisions in fixed point, so
+// lockstep worlds find the same ones on every architecture. It checks
+// all the pairs of its targets in their order after the positions are
+// integrated, so no broad phase is needed.
+// Only circles, rectangles and convex polygons collide, other bodies are left out
+type FixedBodyCollisionDetection struct {
+	Channel string // chan to publish events to
+
+	checkC func(interface{})
+
+	targets    []bodies.Body
+	targetsSet bool
+	world      World
+}
+
+func NewFixedBodyCollisionDetection() Behavior {
+	b := &FixedBodyCollisionDetection{
+		Channel: "collisions:detected",
+	}
+	b.checkC = func(interface{}) { b.check() }
+	return b
+}
+
+func (b *FixedBodyCollisionDetection) ApplyTo(bodies []bodies.Body) {
+	b.targets, b.targetsSet = bodies, bodies != nil
+}
+
+// Targets returns all the bodies of the world until ApplyTo is given a list
+func (b *FixedBodyCollisionDetection) Targets() []bodies.Body {
+	if !b.targetsSet && b.world != nil {
+		return b.world.Bodies()
+	}
+	return b.targets
+}
+func (b *FixedBodyCollisionDetection) AllBodies() bool { return !b.targetsSet }
+func (b *FixedBodyCollisionDetection) SetWorld(world World) {
+	if b.world != nil {
+		// disconnect
+		b.world.Off("integrate:positions", &b.checkC)
+	}
+	if world != nil {
+		// connect
+		world.On("integrate:positions", &b.checkC)
+	}
+	b.world = world
+}
+
+func (b *FixedBodyCollisionDetection) check() {
+	targets := b.Targets()
+	shapes := make([]fixedShape, len(targets))
+	ok := make([]bool, len(targets))
+	for i, body := range targets {
+		shapes[i], ok[i] = newFixedShape(body)
+	}
+
+	collisions := []Collision{}
+	for j, bodyA := range targets {
+		for i := j + 1; i < len(targets); i++ {
+			bodyB := targets[i]
+			// filter out bodies that dont collide with each other
+			if !ok[j] || !ok[i] || !shapes[j].overlaps(shapes[i]) ||
+				bodyA.Treatment() != bodies.TREATMENT_DYNAMIC &&
+					bodyB.Treatment() != bodies.TREATMENT_DYNAMIC {
+				continue
+			}
+
+			c, hit := collideFixed(shapes[j], shapes[i])
+			if !hit {
+				continue
+			}
+			// the values are on the Q32.32 grid, so the float64s are exact
+			collisions = append(collisions, Collision{
+				BodyA:   bodyA,
+				BodyB:   bodyB,
+				Norm:    c.norm.Float(),
+				MTV:     c.norm.Times(c.depth).Float(),
+				Pos:     c.point.Minus(shapes[j].center).Float(),
+				Overlap: c.depth.Float(),
+			})
+		}
+	}
+	if len(collisions) > 0 {
+		b.world.Emit(b.Channel, collisions)
+	}
+}
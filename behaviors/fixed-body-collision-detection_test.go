@@ -0,0 +1,76 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_FixedBodyCollisionDetection(t *testing.T) {
+	Convey("FixedBodyCollisionDetection", t, func() {
+		// the fixed-point collision is close to the float64 one
+		compare := func(bodyA, bodyB bodies.Body) {
+			sA, _ := newFixedShape(bodyA)
+			sB, _ := newFixedShape(bodyB)
+			c, ok := collideFixed(sA, sB)
+			want := CheckBodies(bodyA, bodyB)
+			So(ok, ShouldEqual, len(want) > 0)
+			if !ok {
+				return
+			}
+			So(c.norm.X.Float(), ShouldAlmostEqual, want[0].Norm.X, 1e-6)
+			So(c.norm.Y.Float(), ShouldAlmostEqual, want[0].Norm.Y, 1e-6)
+			So(c.depth.Float(), ShouldAlmostEqual, want[0].Overlap, 1e-3)
+		}
+
+		Convey("should collide circles", func() {
+			a, b := bodies.NewCircle(1), bodies.NewCircle(2)
+			b.SetPosition(2, 1)
+			compare(a, b)
+			b.SetPosition(2.9, 0)
+			compare(a, b)
+			b.SetPosition(4, 0)
+			compare(a, b)
+		})
+
+		Convey("should collide circles with polygons both ways", func() {
+			box := bodies.NewRectangle(4, 2)
+			ball := bodies.NewCircle(1)
+			ball.SetPosition(1, 1.5)
+			compare(box, ball)
+			compare(ball, box)
+			ball.SetPosition(2.5, 1.5)
+			compare(box, ball)
+		})
+
+		Convey("should collide polygons", func() {
+			ground := bodies.NewRectangle(20, 2)
+			ground.SetTreatment(bodies.TREATMENT_STATIC)
+			tri := bodies.NewConvexPolygon([]geom.Vector{{-1, -1}, {1, -1}, {0, 1}})
+			tri.SetPosition(3, -1.8)
+			compare(ground, tri)
+			tri.State().Angular.Pos = 0.3
+			compare(ground, tri)
+			tri.SetPosition(3, -3)
+			compare(ground, tri)
+		})
+
+		Convey("should put the point between the bodies resting on each other", func() {
+			ground := bodies.NewRectangle(20, 2)
+			box := bodies.NewRectangle(2, 2)
+			box.SetPosition(5, -1.9)
+			sA, _ := newFixedShape(ground)
+			sB, _ := newFixedShape(box)
+			c, ok := collideFixed(sA, sB)
+			So(ok, ShouldBeTrue)
+			So(c.point.X.Float(), ShouldAlmostEqual, 5, 1e-6)
+			So(c.norm.Y.Float(), ShouldAlmostEqual, -1, 1e-6)
+		})
+
+		Convey("should leave out the other geometries", func() {
+			_, ok := newFixedShape(bodies.NewCapsule(1, 0.5))
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,175 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/fixed"
+)
+
+// FixedBodyImpulseResponse is BodyImpulseResponse done in Q32.32 fixed
+// point, for the collisions of FixedBodyCollisionDetection. The states
+// are read as fixed-point numbers and written back on the Q32.32 grid.
+// The moments of inertia are worked out again in fixed point,
+// so only circles, rectangles and convex polygons are supported
+type FixedBodyImpulseResponse struct {
+	Channel string
+
+	respondC func(interface{})
+
+	targets []bodies.Body
+	world   World
+}
+
+func NewFixedBodyImpulseResponse() Behavior {
+	b := &FixedBodyImpulseResponse{
+		Channel: "collisions:detected",
+	}
+	b.respondC = func(data interface{}) { b.respond(data.([]Collision)) }
+	return b
+}
+
+func (b *FixedBodyImpulseResponse) ApplyTo(bodies []bodies.Body) { b.targets = bodies }
+func (b *FixedBodyImpulseResponse) Targets() []bodies.Body       { return b.targets }
+func (b *FixedBodyImpulseResponse) SetWorld(world World) {
+	if b.world != nil {
+		// disconnect
+		b.world.Off(b.Channel, &b.respondC)
+	}
+	if world != nil {
+		// connect
+		world.On(b.Channel, &b.respondC)
+	}
+	b.world = world
+}
+
+func (b *FixedBodyImpulseResponse) respond(collisions []Collision) {
+	for _, c := range collisions {
+		b.collideBodies(c.BodyA, c.BodyB,
+			fixed.VectorFrom(c.Norm), fixed.VectorFrom(c.Pos), fixed.VectorFrom(c.MTV))
+	}
+}
+
+func (b *FixedBodyImpulseResponse) collideBodies(bodyA, bodyB bodies.Body, norm, point, mtv fixed.Vector) {
+	// the same rules as BodyImpulseResponse
+	if bodyA.Asleep() && isMoving(bodyB) {
+		bodyA.Wake()
+	}
+	if bodyB.Asleep() && isMoving(bodyA) {
+		bodyB.Wake()
+	}
+	lockedA := bodyA.Treatment() != bodies.TREATMENT_DYNAMIC || bodyA.Asleep()
+	lockedB := bodyB.Treatment() != bodies.TREATMENT_DYNAMIC || bodyB.Asleep()
+
+	// do nothing if both are fixed
+	if lockedA && lockedB {
+		return
+	}
+
+	stateA, stateB := bodyA.State(), bodyB.State()
+	posA, posB := fixed.VectorFrom(stateA.Pos), fixed.VectorFrom(stateB.Pos)
+
+	// extract bodies
+	switch {
+	case lockedA:
+		posB = posB.Plus(mtv)
+	case lockedB:
+		posA = posA.Minus(mtv)
+	default:
+		mtv = fixed.Vector{mtv.X / 2, mtv.Y / 2}
+		posA = posA.Minus(mtv)
+		posB = posB.Plus(mtv)
+	}
+	stateA.Pos, stateB.Pos = posA.Float(), posB.Float()
+
+	// inverse masses and moments of inertia.
+	// give fixed bodies infinite mass and moi
+	var invMoiA, invMoiB, invMassA, invMassB fixed.Fixed
+	if !lockedA {
+		if moi := fixedMOI(bodyA); moi != 0 {
+			invMoiA = fixed.One.Div(moi)
+		}
+		invMassA = fixed.One.Div(fixed.FromFloat(bodyA.Mass()))
+	}
+	if !lockedB {
+		if moi := fixedMOI(bodyB); moi != 0 {
+			invMoiB = fixed.One.Div(moi)
+		}
+		invMassB = fixed.One.Div(fixed.FromFloat(bodyB.Mass()))
+	}
+
+	cor := fixed.FromFloat(bodyA.Restitution()).Mul(fixed.FromFloat(bodyB.Restitution()))
+	cof := fixed.FromFloat(bodyA.Cof()).Mul(fixed.FromFloat(bodyB.Cof()))
+	scof := fixed.FromFloat(bodyA.StaticCof()).Mul(fixed.FromFloat(bodyB.StaticCof()))
+
+	perp := norm.Perp(false)
+
+	// the centroids of the supported geometries are at the origin
+	rA := point
+	rB := point.Plus(posA).Minus(posB)
+
+	velA, velB := fixed.VectorFrom(stateA.Vel), fixed.VectorFrom(stateB.Vel)
+	angVelA, angVelB := fixed.FromFloat(stateA.Angular.Vel), fixed.FromFloat(stateB.Angular.Vel)
+
+	// relative velocity towards B at collision point
+	vAB := velB.
+		Plus(rB.Perp(false).Times(angVelB)).
+		Minus(velA).
+		Minus(rA.Perp(false).Times(angVelA))
+
+	rAproj, rAreg := rA.Dot(norm), rA.Dot(perp)
+	rBproj, rBreg := rB.Dot(norm), rB.Dot(perp)
+	vproj, vreg := vAB.Dot(norm), vAB.Dot(perp)
+
+	// if moving away from each other... dont' bother.
+	if vproj >= 0 {
+		return
+	}
+
+	impulse := -(fixed.One + cor).Mul(vproj).Div(
+		invMassA + invMassB + invMoiA.Mul(rAreg).Mul(rAreg) + invMoiB.Mul(rBreg).Mul(rBreg))
+
+	// apply impulse
+	if !lockedB {
+		velB = velB.Plus(norm.Times(impulse.Mul(invMassB)))
+		angVelB -= impulse.Mul(invMoiB).Mul(rBreg)
+	}
+	if !lockedA {
+		velA = velA.Minus(norm.Times(impulse.Mul(invMassA)))
+		angVelA += impulse.Mul(invMoiA).Mul(rAreg)
+	}
+
+	// if we have friction and a relative velocity perpendicular to the normal
+	if (cof != 0 || scof != 0) && vreg != 0 {
+		// the impulse needed to stop the sliding
+		max := vreg.Div(invMassA + invMassB + invMoiA.Mul(rAproj).Mul(rAproj) + invMoiB.Mul(rBproj).Mul(rBproj))
+
+		if max.Abs() <= scof.Mul(impulse) {
+			// the bodies stick
+			impulse = max
+		} else {
+			// kinetic friction, never more than stops the sliding
+			impulse = impulse.Mul(cof)
+			if vreg > 0 {
+				impulse = fixed.Min(impulse, max)
+			} else {
+				impulse = fixed.Max(-impulse, max)
+			}
+		}
+
+		// apply frictional impulse
+		if !lockedB {
+			velB = velB.Minus(perp.Times(impulse.Mul(invMassB)))
+			angVelB -= impulse.Mul(invMoiB).Mul(rBproj)
+		}
+		if !lockedA {
+			velA = velA.Plus(perp.Times(impulse.Mul(invMassA)))
+			angVelA += impulse.Mul(invMoiA).Mul(rAproj)
+		}
+	}
+
+	if !lockedA {
+		stateA.Vel, stateA.Angular.Vel = velA.Float(), angVelA.Float()
+	}
+	if !lockedB {
+		stateB.Vel, stateB.Angular.Vel = velB.Float(), angVelB.Float()
+	}
+}
@@ -0,0 +1,50 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/fixed"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_FixedBodyImpulseResponse(t *testing.T) {
+	Convey("FixedBodyImpulseResponse", t, func() {
+		newBodies := func() (ball, floor bodies.Body) {
+			ball = bodies.NewCircle(10)
+			ball.SetRestitution(0.5)
+			ball.SetVelocity(1, 5)
+			ball.State().Angular.Vel = 0.01
+			floor = bodies.NewRectangle(100, 10)
+			floor.SetTreatment(bodies.TREATMENT_STATIC)
+			floor.SetPosition(0, 15)
+			return
+		}
+		norm, point, mtv := geom.Vector{0, 1}, geom.Vector{0, 10}, geom.Vector{0, 0.5}
+
+		Convey("should respond like BodyImpulseResponse", func() {
+			ball, floor := newBodies()
+			NewFixedBodyImpulseResponse().(*FixedBodyImpulseResponse).collideBodies(ball, floor,
+				fixed.VectorFrom(norm), fixed.VectorFrom(point), fixed.VectorFrom(mtv))
+			want, wantFloor := newBodies()
+			NewBodyImpulseResponse().(*BodyImpulseResponse).collideBodies(want, wantFloor, norm, point, mtv, false)
+
+			So(ball.State().Pos, ShouldResemble, want.State().Pos)
+			So(ball.State().Vel.X, ShouldAlmostEqual, want.State().Vel.X, 1e-6)
+			So(ball.State().Vel.Y, ShouldAlmostEqual, want.State().Vel.Y, 1e-6)
+			So(ball.State().Angular.Vel, ShouldAlmostEqual, want.State().Angular.Vel, 1e-6)
+			So(floor.State().Vel, ShouldResemble, geom.Vector{})
+		})
+
+		Convey("should leave the states on the Q32.32 grid", func() {
+			ball, floor := newBodies()
+			ball.SetVelocity(0.1, 0.3)
+			NewFixedBodyImpulseResponse().(*FixedBodyImpulseResponse).collideBodies(ball, floor,
+				fixed.VectorFrom(norm), fixed.VectorFrom(point), fixed.VectorFrom(mtv))
+			state := ball.State()
+			for _, v := range []float64{state.Pos.X, state.Pos.Y, state.Vel.X, state.Vel.Y, state.Angular.Vel} {
+				So(fixed.FromFloat(v).Float(), ShouldEqual, v)
+			}
+		})
+	})
+}
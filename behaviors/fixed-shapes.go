@@ -0,0 +1,257 @@
+package behaviors
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/fixed"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// fixedShape is a circle or a convex polygon placed in the world,
+// in fixed point
+type fixedShape struct {
+	center   fixed.Vector
+	radius   fixed.Fixed    // circles only
+	vertices []fixed.Vector // polygons only
+	min, max fixed.Vector
+}
+
+// newFixedShape places the geometry of the body. ok is false for
+// the geometries the fixed-point pipeline doesn't collide
+func newFixedShape(body bodies.Body) (s fixedShape, ok bool) {
+	state := body.State()
+	s.center = fixed.VectorFrom(state.Pos)
+
+	var local []fixed.Vector
+	switch g := body.Geometry().(type) {
+	case *geometries.Circle:
+		s.radius = fixed.FromFloat(g.Radius)
+		r := fixed.Vector{s.radius, s.radius}
+		s.min, s.max = s.center.Minus(r), s.center.Plus(r)
+		return s, true
+	case *geometries.Rectangle:
+		hw, hh := fixed.FromFloat(g.Width)/2, fixed.FromFloat(g.Height)/2
+		local = []fixed.Vector{{-hw, -hh}, {hw, -hh}, {hw, hh}, {-hw, hh}}
+	case *geometries.ConvexPolygon:
+		for _, v := range g.Vertices {
+			local = append(local, fixed.VectorFrom(v))
+		}
+	default:
+		return s, false
+	}
+
+	trans := fixed.NewTransform(s.center, fixed.FromFloat(state.Angular.Pos), fixed.Vector{})
+	for i, v := range local {
+		v = trans.Translate(trans.Rotate(v))
+		s.vertices = append(s.vertices, v)
+		if i == 0 {
+			s.min, s.max = v, v
+			continue
+		}
+		s.min = fixed.Vector{fixed.Min(s.min.X, v.X), fixed.Min(s.min.Y, v.Y)}
+		s.max = fixed.Vector{fixed.Max(s.max.X, v.X), fixed.Max(s.max.Y, v.Y)}
+	}
+	return s, len(s.vertices) > 2
+}
+
+func (s fixedShape) overlaps(o fixedShape) bool {
+	return s.min.X <= o.max.X && o.min.X <= s.max.X &&
+		s.min.Y <= o.max.Y && o.min.Y <= s.max.Y
+}
+
+// fixedMOI is the moment of inertia of the body about its center,
+// the same as geometries.GeometryMOI times the mass
+func fixedMOI(body bodies.Body) fixed.Fixed {
+	mass := fixed.FromFloat(body.Mass())
+	switch g := body.Geometry().(type) {
+	case *geometries.Circle:
+		r := fixed.FromFloat(g.Radius)
+		return mass.Mul(r.Mul(r)) / 2
+	case *geometries.Rectangle:
+		w, h := fixed.FromFloat(g.Width), fixed.FromFloat(g.Height)
+		return mass.Mul(w.Mul(w)+h.Mul(h)) / 12
+	case *geometries.ConvexPolygon:
+		var num, denom fixed.Fixed
+		prev := fixed.VectorFrom(g.Vertices[len(g.Vertices)-1])
+		for _, v := range g.Vertices {
+			next := fixed.VectorFrom(v)
+			tmp := next.Cross(prev).Abs()
+			num += tmp.Mul(next.MagnitudeSquared() + prev.Dot(next) + prev.MagnitudeSquared())
+			denom += tmp
+			prev = next
+		}
+		if denom == 0 {
+			return 0
+		}
+		return mass.Mul(num.Div(denom)) / 6
+	}
+	return 0
+}
+
+// fixedContact is a collision in the world, norm goes from A to B
+type fixedContact struct {
+	norm  fixed.Vector
+	depth fixed.Fixed
+	point fixed.Vector
+}
+
+func collideFixed(a, b fixedShape) (c fixedContact, ok bool) {
+	switch {
+	case a.vertices == nil && b.vertices == nil:
+		return collideCircles(a, b)
+	case b.vertices == nil:
+		return collidePolygonCircle(a, b)
+	case a.vertices == nil:
+		c, ok = collidePolygonCircle(b, a)
+		c.norm = fixed.Vector{-c.norm.X, -c.norm.Y}
+		return
+	}
+	return collidePolygons(a, b)
+}
+
+func collideCircles(a, b fixedShape) (c fixedContact, ok bool) {
+	d := b.center.Minus(a.center)
+	r := a.radius + b.radius
+	if d.MagnitudeSquared() >= r.Mul(r) {
+		return c, false
+	}
+	dist := d.Magnitude()
+	c.norm = fixed.Vector{0, fixed.One}
+	if dist != 0 {
+		c.norm = fixed.Vector{d.X.Div(dist), d.Y.Div(dist)}
+	}
+	c.depth = r - dist
+	c.point = a.center.Plus(c.norm.Times(a.radius))
+	return c, true
+}
+
+// outward returns the unit normal of the edge from a to b
+// that points away from the center of the polygon
+func outward(a, b, center fixed.Vector) fixed.Vector {
+	n := b.Minus(a).Perp(false).Unit()
+	if n.Dot(a.Minus(center)) < 0 {
+		n = fixed.Vector{-n.X, -n.Y}
+	}
+	return n
+}
+
+func closestOnSegment(a, b, p fixed.Vector) fixed.Vector {
+	ab := b.Minus(a)
+	length := ab.MagnitudeSquared()
+	if length == 0 {
+		return a
+	}
+	t := p.Minus(a).Dot(ab).Div(length)
+	t = fixed.Max(0, fixed.Min(fixed.One, t))
+	return a.Plus(ab.Times(t))
+}
+
+func collidePolygonCircle(p, circle fixedShape) (c fixedContact, ok bool) {
+	n := len(p.vertices)
+	inside := true
+	var closest, edgeNorm fixed.Vector
+	var best fixed.Fixed
+	for i, a := range p.vertices {
+		b := p.vertices[(i+1)%n]
+		norm := outward(a, b, p.center)
+		if circle.center.Minus(a).Dot(norm) > 0 {
+			inside = false
+		}
+		q := closestOnSegment(a, b, circle.center)
+		if dsq := circle.center.Minus(q).MagnitudeSquared(); i == 0 || dsq < best {
+			best, closest, edgeNorm = dsq, q, norm
+		}
+	}
+
+	dist := best.Sqrt()
+	switch {
+	case inside:
+		c.norm = edgeNorm
+		c.depth = circle.radius + dist
+	case best >= circle.radius.Mul(circle.radius):
+		return c, false
+	case dist == 0:
+		c.norm = edgeNorm
+		c.depth = circle.radius
+	default:
+		d := circle.center.Minus(closest)
+		c.norm = fixed.Vector{d.X.Div(dist), d.Y.Div(dist)}
+		c.depth = circle.radius - dist
+	}
+	c.point = closest
+	return c, true
+}
+
+func project(vertices []fixed.Vector, axis fixed.Vector) (min, max fixed.Fixed) {
+	for i, v := range vertices {
+		d := v.Dot(axis)
+		if i == 0 || d < min {
+			min = d
+		}
+		if i == 0 || d > max {
+			max = d
+		}
+	}
+	return
+}
+
+// contains checks if the point is in the polygon or on its edges
+func (s fixedShape) contains(pt fixed.Vector) bool {
+	for i, a := range s.vertices {
+		b := s.vertices[(i+1)%len(s.vertices)]
+		if pt.Minus(a).Dot(outward(a, b, s.center)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// collidePolygons uses the separating axis test. The collision point is
+// the middle of the vertices of each polygon that are in the other one
+func collidePolygons(a, b fixedShape) (c fixedContact, ok bool) {
+	first := true
+	for _, s := range []fixedShape{a, b} {
+		for i, v := range s.vertices {
+			axis := outward(v, s.vertices[(i+1)%len(s.vertices)], s.center)
+			minA, maxA := project(a.vertices, axis)
+			minB, maxB := project(b.vertices, axis)
+			depth := fixed.Min(maxA-minB, maxB-minA)
+			if depth <= 0 {
+				return c, false
+			}
+			if first || depth < c.depth {
+				first = false
+				c.depth = depth
+				c.norm = axis
+			}
+		}
+	}
+	if b.center.Minus(a.center).Dot(c.norm) < 0 {
+		c.norm = fixed.Vector{-c.norm.X, -c.norm.Y}
+	}
+
+	var sum fixed.Vector
+	count := 0
+	for _, v := range b.vertices {
+		if a.contains(v) {
+			sum = sum.Plus(v)
+			count++
+		}
+	}
+	for _, v := range a.vertices {
+		if b.contains(v) {
+			sum = sum.Plus(v)
+			count++
+		}
+	}
+	if count == 0 {
+		// they cross without a vertex inside, use the deepest one of B
+		min, _ := project(b.vertices, c.norm)
+		for _, v := range b.vertices {
+			if v.Dot(c.norm) == min {
+				return fixedContact{c.norm, c.depth, v}, true
+			}
+		}
+	}
+	c.point = fixed.Vector{sum.X / fixed.Fixed(count), sum.Y / fixed.Fixed(count)}
+	return c, true
+}
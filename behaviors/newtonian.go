@@ -11,8 +11,9 @@ type Newtonian struct {
 
 	behaveC func(interface{})
 
-	targets []bodies.Body
-	world   World
+	targets    []bodies.Body
+	targetsSet bool
+	world      World
 }
 
 func NewNewtonian(strength float64) Behavior {
@@ -26,18 +27,21 @@ func NewNewtonian(strength float64) Behavior {
 }
 
 func (b *Newtonian) ApplyTo(bodies []bodies.Body) {
-	b.targets = bodies
+	b.targets, b.targetsSet = bodies, bodies != nil
 }
+
+// Targets returns all the bodies of the world until ApplyTo is given a list
 func (b *Newtonian) Targets() []bodies.Body {
-	if len(b.targets) == 0 {
+	if !b.targetsSet && b.world != nil {
 		return b.world.Bodies()
 	}
 	return b.targets
 }
+func (b *Newtonian) AllBodies() bool { return !b.targetsSet }
 func (b *Newtonian) SetWorld(world World) {
 	if b.world != nil {
 		// disconnect
-		world.Off("integrate:positions", &b.behaveC)
+		b.world.Off("integrate:positions", &b.behaveC)
 	}
 	if world != nil {
 		// connect
@@ -51,6 +55,13 @@ func (b *Newtonian) SetMinMax(min, max float64) {
 	b.maxSq = max * max
 }
 
+// SquaredLimits returns the squared distances set by SetMinMax
+func (b *Newtonian) SquaredLimits() (minSq, maxSq float64) { return b.minSq, b.maxSq }
+func (b *Newtonian) SetSquaredLimits(minSq, maxSq float64) {
+	b.minSq = minSq
+	b.maxSq = maxSq
+}
+
 func (b *Newtonian) behave() {
 	targets := b.Targets()
 	for j, body := range targets {
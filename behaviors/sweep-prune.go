@@ -13,12 +13,6 @@ const (
 )
 
 var dof = geom.Vector{0, 1}
-var uid int = 0
-
-func getUniqueId() int {
-	uid++
-	return uid
-}
 
 type tracker struct {
 	id       int
@@ -38,6 +32,10 @@ type SweepPrune struct {
 
 	axis int
 
+	// ids of the trackers are counted per instance,
+	// so they are the same in every run
+	lastId int
+
 	world World
 }
 
@@ -59,9 +57,9 @@ func (b *SweepPrune) Targets() []bodies.Body       { return nil }
 func (b *SweepPrune) SetWorld(world World) {
 	if b.world != nil {
 		// disconnect
-		world.Off("add:body", &b.trackBodyC)
-		world.Off("remove:body", &b.untrackBodyC)
-		world.Off("integrate:velocities", &b.sweepC)
+		b.world.Off("add:body", &b.trackBodyC)
+		b.world.Off("remove:body", &b.untrackBodyC)
+		b.world.Off("integrate:velocities", &b.sweepC)
 		b.clear()
 	}
 	if world != nil {
@@ -80,10 +78,44 @@ func (b *SweepPrune) clear() {
 	}
 }
 
+type sweepPruneState struct {
+	tracked [][]*tracker
+	lastId  int
+}
+
+// SaveState copies the tracked bodies in their sorted order,
+// which decides the order of the candidates
+func (b *SweepPrune) SaveState() interface{} {
+	return sweepPruneState{copyTracked(b.tracked), b.lastId}
+}
+
+func (b *SweepPrune) RestoreState(state interface{}) {
+	s := state.(sweepPruneState)
+	b.tracked = copyTracked(s.tracked)
+	b.lastId = s.lastId
+}
+
+func copyTracked(tracked [][]*tracker) [][]*tracker {
+	copies := map[*tracker]*tracker{}
+	result := make([][]*tracker, len(tracked))
+	for xyz, list := range tracked {
+		result[xyz] = make([]*tracker, len(list))
+		for i, tr := range list {
+			if copies[tr] == nil {
+				c := *tr
+				copies[tr] = &c
+			}
+			result[xyz][i] = copies[tr]
+		}
+	}
+	return result
+}
+
 func (b *SweepPrune) trackBody(body bodies.Body) {
 	// TODO
+	b.lastId++
 	tracker := &tracker{
-		id:   getUniqueId(),
+		id:   b.lastId,
 		body: body,
 	}
 
@@ -96,7 +128,8 @@ func (b *SweepPrune) untrackBody(body bodies.Body) {
 	for xyz := range b.tracked {
 		for i, tracker := range b.tracked[xyz] {
 			if tracker.body == body {
-				b.tracked[xyz] = append(b.tracked[xyz][i:], b.tracked[xyz][:i+1]...)
+				b.tracked[xyz] = append(b.tracked[xyz][:i], b.tracked[xyz][i+1:]...)
+				break
 			}
 		}
 	}
@@ -112,9 +145,9 @@ func (b *SweepPrune) sweep() {
 func (b *SweepPrune) broadPhase() map[int]*pair {
 	for xyz := range b.tracked {
 		for _, tr := range b.tracked[xyz] {
-			aabb := tr.body.AABB(0)
+			aabb := tr.body.AABB(tr.body.State().Angular.Pos)
 			span := geom.Vector{aabb.HW, aabb.HH}
-			pos := tr.body.State().Pos
+			pos := geom.Vector{aabb.X, aabb.Y}
 			tr.min = pos.Minus(span)
 			tr.max = pos.Plus(span)
 		}
@@ -140,7 +173,8 @@ func (a byX) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a byX) Less(i, j int) bool {
 	//first, second := a[i].value().X, a[j].value().X
 	first, second := a[i].min.X, a[j].min.X
-	return first < second //|| first == second && !a[i].isMax && a[j].isMax
+	// ties go by id, so the order doesn't depend on the previous one
+	return first < second || first == second && a[i].id < a[j].id
 }
 
 type byY []*tracker
@@ -149,7 +183,7 @@ func (a byY) Len() int      { return len(a) }
 func (a byY) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a byY) Less(i, j int) bool {
 	first, second := a[i].min.Y, a[j].min.Y
-	return first < second //|| first == second && !a[i].isMax && a[j].isMax
+	return first < second || first == second && a[i].id < a[j].id
 }
 
 func (b *SweepPrune) checkOverlaps() map[int]*pair {
@@ -16,9 +16,13 @@ type BodyState struct {
 }
 
 const (
-	TREATMENT_DYNAMIC   = 1
+	// moved by forces and contacts
+	TREATMENT_DYNAMIC = 1
+	// moved only by the velocity you set. never affected by forces or
+	// contacts, but pushes and carries dynamic bodies it touches
 	TREATMENT_KINEMATIC = 2
-	TREATMENT_STATIC    = 3
+	// never moves
+	TREATMENT_STATIC = 3
 )
 
 type Body interface {
@@ -28,6 +32,8 @@ type Body interface {
 
 	Cof() float64
 	SetCof(float64)
+	StaticCof() float64
+	SetStaticCof(float64)
 
 	Geometry() geometries.Geometry
 	Hidden() bool
@@ -35,10 +41,28 @@ type Body interface {
 
 	Mass() float64
 	SetMass(float64)
+	Density() float64
+	SetDensity(float64)
+	// center of mass in local coordinates
+	Centroid() geom.Vector
 	// init
 	//Options() Options
 
 	Recalc()
+	Refit()
+
+	// size relative to the geometry it was made with
+	Scale() float64
+	SetScale(float64)
+
+	Asleep() bool
+	Sleep()
+	Wake()
+	IdleTicks() uint64
+	SetIdleTicks(uint64)
+
+	// the world sets the function Refit calls
+	SetOnRefit(func())
 
 	Restitution() float64
 	SetRestitution(float64)
@@ -50,6 +74,7 @@ type Body interface {
 	SetTreatment(uint)
 
 	UID() int64
+	SetUID(int64)
 	View() interface{}
 	SetView(interface{})
 
@@ -57,4 +82,7 @@ type Body interface {
 	SetVelocity(float64, float64)
 
 	MOI() float64
+
+	Snapshot() BodySnapshot
+	Restore(BodySnapshot)
 }
@@ -1,14 +1,127 @@
 package bodies
 
 import (
+	"github.com/oniproject/physics.go/geom"
 	. "github.com/smartystreets/goconvey/convey"
+	"image"
+	"image/color"
+	"math"
 	"testing"
 )
 
 func Test_Bodies(t *testing.T) {
 	Convey("Test_Bodies", t, func() {
 		Convey("Circle", func() {
+			c := NewCircle(2)
+			So(c.MOI(), ShouldAlmostEqual, 2)
+			So(c.Density(), ShouldAlmostEqual, 1/(4*math.Pi))
+		})
+
+		Convey("SetMass should recompute the moment of inertia", func() {
+			r := NewRectangle(2, 4)
+			r.SetMass(3)
+			So(r.MOI(), ShouldAlmostEqual, 3*(4+16)/12.0)
+			So(r.Density(), ShouldAlmostEqual, 3/8.0)
+
+			p := NewConvexPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}})
+			unit := p.MOI()
+			p.SetMass(5)
+			So(p.MOI(), ShouldAlmostEqual, 5*unit)
+		})
+
+		Convey("SetDensity should derive the mass from the area", func() {
+			r := NewRectangle(2, 4)
+			r.SetDensity(2)
+			So(r.Mass(), ShouldAlmostEqual, 16)
+			So(r.MOI(), ShouldAlmostEqual, 16*(4+16)/12.0)
+		})
+
+		Convey("Segment centroid is off the origin", func() {
+			s := NewSegment(geom.Vector{2, 0}, geom.Vector{4, 0})
+			So(s.Centroid(), ShouldResemble, geom.Vector{3, 0})
+			// about its middle, not the origin
+			So(s.MOI(), ShouldAlmostEqual, 4/12.0)
+		})
+
+		Convey("SetScale should keep the density", func() {
+			r := NewRectangle(2, 1)
+			r.SetDensity(3)
+			r.Sleep()
+
+			r.SetScale(2)
+			So(r.Scale(), ShouldEqual, 2)
+			So(r.Mass(), ShouldAlmostEqual, 3*8)
+			So(r.MOI(), ShouldAlmostEqual, 3*8*(16+4)/12.0)
+			So(r.Asleep(), ShouldBeFalse)
+
+			r.SetScale(1)
+			So(r.Mass(), ShouldAlmostEqual, 3*2)
+		})
+
+		Convey("SetVertices should keep the vertices in place", func() {
+			p := NewConvexPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}).(*ConvexPolygon)
+			p.SetPosition(10, 0)
+			So(p.SetVertices([]geom.Vector{{0, 0}, {4, 0}, {4, 2}, {0, 2}}), ShouldBeNil)
+
+			So(p.State().Pos, ShouldResemble, geom.Vector{12, 1})
+			So(p.Mass(), ShouldAlmostEqual, 8*p.Density())
+		})
+
+		Convey("Slice should share the mass and the motion", func() {
+			p := NewConvexPolygon([]geom.Vector{{-2, -1}, {2, -1}, {2, 1}, {-2, 1}}).(*ConvexPolygon)
+			p.SetMass(8)
+			p.SetPosition(10, 0)
+			p.State().Angular.Vel = 1
+
+			pieces, ok := p.Slice(geom.Vector{10, -5}, geom.Vector{10, 5})
+			So(ok, ShouldBeTrue)
+			So(pieces, ShouldHaveLength, 2)
+			So(pieces[0].Mass()+pieces[1].Mass(), ShouldAlmostEqual, 8)
+
+			for _, piece := range pieces {
+				state := piece.State()
+				So(math.Abs(state.Pos.X-10), ShouldAlmostEqual, 1)
+				// spinning around the old center
+				So(math.Abs(state.Vel.Y), ShouldAlmostEqual, 1)
+				So(state.Angular.Vel, ShouldEqual, 1)
+			}
+			So(pieces[0].State().Vel.Y, ShouldAlmostEqual, -pieces[1].State().Vel.Y)
+		})
+
+		Convey("should build static bodies from an image", func() {
+			img := image.NewAlpha(image.Rect(0, 0, 10, 10))
+			for y := 5; y < 10; y++ {
+				for x := 0; x < 10; x++ {
+					img.SetAlpha(x, y, color.Alpha{255})
+				}
+			}
+
+			chains := NewChainsFromImage(img, 128, 0.1)
+			So(chains, ShouldHaveLength, 1)
+			So(chains[0].Treatment(), ShouldEqual, TREATMENT_STATIC)
+
+			polygons, err := NewPolygonsFromImage(img, 128, 0.1)
+			So(err, ShouldBeNil)
+			So(len(polygons), ShouldBeGreaterThan, 0)
+			for _, p := range polygons {
+				So(p.Treatment(), ShouldEqual, TREATMENT_STATIC)
+			}
+		})
+
+		Convey("should merge tiles into static bodies", func() {
+			grid := [][]int{
+				{0, 0, 0, 0},
+				{1, 1, 1, 1},
+				{1, 1, 1, 1},
+			}
+			chains := NewChainsFromTiles(grid, 16)
+			So(chains, ShouldHaveLength, 1)
+			So(chains[0].Geometry().Outline(0), ShouldHaveLength, 4)
 
+			polygons, err := NewPolygonsFromTiles(grid, 16)
+			So(err, ShouldBeNil)
+			So(polygons, ShouldHaveLength, 1)
+			So(polygons[0].Geometry().Area(), ShouldAlmostEqual, 64*32)
 		})
 	})
 }
@@ -0,0 +1,16 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geometries"
+)
+
+type Capsule struct {
+	Point
+}
+
+func NewCapsule(halfLength, radius float64) Body {
+	c := &Capsule{Point: *NewPoint()}
+	c.geometry = geometries.NewCapsule(halfLength, radius)
+	c.Recalc()
+	return c
+}
@@ -16,9 +16,3 @@ func NewCircle(radius float64) Body {
 	c.Recalc()
 	return c
 }
-
-func (this *Circle) Recalc() {
-	c := this.geometry.(*geometries.Circle)
-	// moment of inertia
-	this.moi = this.mass * c.Radius * c.Radius / 2.0
-}
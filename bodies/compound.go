@@ -0,0 +1,20 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geometries"
+)
+
+type Compound struct {
+	Point
+}
+
+func NewCompound(children []geometries.Child) Body {
+	return newCompound(geometries.NewCompound(children))
+}
+
+func newCompound(geometry *geometries.Compound) Body {
+	c := &Compound{Point: *NewPoint()}
+	c.geometry = geometry
+	c.Recalc()
+	return c
+}
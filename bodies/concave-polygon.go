@@ -0,0 +1,16 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// NewConcavePolygon returns a compound body made of
+// the convex parts of the outline
+func NewConcavePolygon(outline []geom.Vector) (Body, error) {
+	geometry, err := geometries.NewConcavePolygon(outline)
+	if err != nil {
+		return nil, err
+	}
+	return newCompound(geometry), nil
+}
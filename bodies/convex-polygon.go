@@ -16,8 +16,38 @@ func NewConvexPolygon(vertices []geom.Vector) Body {
 	return c
 }
 
-func (this *ConvexPolygon) Recalc() {
-	v := this.geometry.(*geometries.ConvexPolygon)
-	// moment of inertia
-	this.moi = geometries.PolygonMOI(v.Vertices)
+// NewConvexPolygonFromPoints accepts unordered points
+// and uses their convex hull
+func NewConvexPolygonFromPoints(points []geom.Vector) (Body, error) {
+	geometry, err := geometries.NewConvexPolygonFromPoints(points)
+	if err != nil {
+		return nil, err
+	}
+	c := &ConvexPolygon{Point: *NewPoint()}
+	c.geometry = geometry
+	c.Recalc()
+	return c, nil
+}
+
+// SetVertices changes the shape, the vertices are scaled by the current scale.
+// the body is moved so they stay where they are in the world
+func (this *ConvexPolygon) SetVertices(hull []geom.Vector) error {
+	return this.setVertices(this.geometry.(*geometries.ConvexPolygon), hull)
+}
+
+func (p *Point) setVertices(poly *geometries.ConvexPolygon, hull []geom.Vector) error {
+	scaled := make([]geom.Vector, len(hull))
+	for i, v := range hull {
+		scaled[i] = v.Times(p.scale)
+	}
+
+	if err := poly.SetVertices(scaled); err != nil {
+		return err
+	}
+
+	// the geometry moved its centroid to the origin
+	center := geometries.PolygonCentroid(scaled)
+	p.state.Pos = p.state.Pos.Plus(geom.NewTransformAngle(p.state.Angular.Pos).Rotate(center))
+	p.Refit()
+	return nil
 }
@@ -0,0 +1,52 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	"image"
+)
+
+// NewChainsFromImage traces the alpha mask of the image and returns
+// a static looped chain for every outline and hole. tolerance is how far
+// (in pixels) the simplified outlines may be from the traced ones
+func NewChainsFromImage(img image.Image, threshold uint8, tolerance float64) []Body {
+	list := []Body{}
+	for _, loop := range geometries.TraceImage(img, threshold) {
+		loop = geometries.SimplifyPolygon(loop, tolerance)
+		if len(loop) < 3 {
+			continue
+		}
+		list = append(list, NewChain(loop, true))
+	}
+	return list
+}
+
+// NewPolygonsFromImage traces the alpha mask of the image and
+// returns static convex polygons covering the solid pixels
+func NewPolygonsFromImage(img image.Image, threshold uint8, tolerance float64) ([]Body, error) {
+	loops := [][]geom.Vector{}
+	for _, loop := range geometries.TraceImage(img, threshold) {
+		loop = geometries.SimplifyPolygon(loop, tolerance)
+		if len(loop) >= 3 {
+			loops = append(loops, loop)
+		}
+	}
+
+	outlines, err := geometries.FillContours(loops)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []Body{}
+	for _, outline := range outlines {
+		parts, err := NewConvexPolygons(outline)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			part.SetTreatment(TREATMENT_STATIC)
+			list = append(list, part)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,199 @@
+package bodies
+
+import (
+	"errors"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+var ERROR_UNKNOWN_BODY = errors.New("Error: Unknown body type.")
+var ERROR_UNKNOWN_TREATMENT = errors.New("Error: Unknown treatment.")
+
+var treatmentNames = map[uint]string{
+	TREATMENT_DYNAMIC:   "dynamic",
+	TREATMENT_KINEMATIC: "kinematic",
+	TREATMENT_STATIC:    "static",
+}
+
+type StateData struct {
+	Pos        geom.Vector `json:"pos"`
+	Vel        geom.Vector `json:"vel"`
+	Acc        geom.Vector `json:"acc"`
+	Angle      float64     `json:"angle"`
+	AngularVel float64     `json:"angularVel"`
+	AngularAcc float64     `json:"angularAcc"`
+}
+
+// BodyData is the JSON form of a body. Encoded bodies have every field set
+// and decode exactly the same. In hand written data the missing fields
+// take the defaults of the body type, and the mass properties are derived
+// from the mass or density unless all of them are given
+type BodyData struct {
+	Type     string                  `json:"type"`
+	Geometry geometries.GeometryData `json:"geometry"`
+
+	State StateData `json:"state"`
+	Old   StateData `json:"old"`
+
+	Treatment string `json:"treatment,omitempty"`
+	Hidden    bool   `json:"hidden,omitempty"`
+	Asleep    bool   `json:"asleep,omitempty"`
+	Idle      uint64 `json:"idle,omitempty"` // ticks the body has been still
+
+	Mass     *float64     `json:"mass,omitempty"`
+	Density  *float64     `json:"density,omitempty"`
+	MOI      *float64     `json:"moi,omitempty"`
+	Centroid *geom.Vector `json:"centroid,omitempty"`
+	Scale    *float64     `json:"scale,omitempty"`
+
+	Restitution *float64 `json:"restitution,omitempty"`
+	Cof         *float64 `json:"cof,omitempty"`
+	StaticCof   *float64 `json:"staticCof,omitempty"`
+}
+
+func EncodeBody(body Body) (d BodyData, err error) {
+	switch body.(type) {
+	case *Point:
+		d.Type = "point"
+	case *Circle:
+		d.Type = "circle"
+	case *Rectangle:
+		d.Type = "rectangle"
+	case *Capsule:
+		d.Type = "capsule"
+	case *ConvexPolygon:
+		d.Type = "convex-polygon"
+	case *RoundedPolygon:
+		d.Type = "rounded-polygon"
+	case *Segment:
+		d.Type = "segment"
+	case *Chain:
+		d.Type = "chain"
+	case *Heightfield:
+		d.Type = "heightfield"
+	case *Compound:
+		d.Type = "compound"
+	default:
+		return d, ERROR_UNKNOWN_BODY
+	}
+
+	if d.Geometry, err = geometries.EncodeGeometry(body.Geometry()); err != nil {
+		return
+	}
+
+	s := body.Snapshot()
+	d.State = encodeState(s.State)
+	d.Old = encodeState(s.Old)
+	if d.Treatment = treatmentNames[s.Treatment]; d.Treatment == "" {
+		return d, ERROR_UNKNOWN_TREATMENT
+	}
+	d.Hidden, d.Asleep, d.Idle = s.Hidden, s.Asleep, s.Idle
+	d.Mass, d.Density, d.MOI = &s.Mass, &s.Density, &s.MOI
+	d.Centroid, d.Scale = &s.Centroid, &s.Scale
+	d.Restitution, d.Cof, d.StaticCof = &s.Restitution, &s.Cof, &s.StaticCof
+	return
+}
+
+func DecodeBody(d BodyData) (Body, error) {
+	geometry, err := geometries.DecodeGeometry(d.Geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewPoint()
+	p.geometry = geometry
+
+	var body Body
+	switch d.Type {
+	case "point":
+		body = p
+	case "circle":
+		body = &Circle{Point: *p}
+	case "rectangle":
+		body = &Rectangle{Point: *p}
+	case "capsule":
+		body = &Capsule{Point: *p}
+	case "convex-polygon":
+		body = &ConvexPolygon{Point: *p}
+	case "rounded-polygon":
+		body = &RoundedPolygon{Point: *p}
+	case "segment":
+		body = &Segment{Point: *p}
+		body.SetTreatment(TREATMENT_STATIC)
+	case "chain":
+		body = &Chain{Point: *p}
+		body.SetTreatment(TREATMENT_STATIC)
+	case "heightfield":
+		body = &Heightfield{Point: *p}
+		body.SetTreatment(TREATMENT_STATIC)
+	case "compound":
+		body = &Compound{Point: *p}
+	default:
+		return nil, ERROR_UNKNOWN_BODY
+	}
+	body.Recalc()
+
+	s := body.Snapshot()
+	s.State = decodeState(d.State)
+	s.Old = decodeState(d.Old)
+	if d.Treatment != "" {
+		s.Treatment = 0
+		for t, name := range treatmentNames {
+			if name == d.Treatment {
+				s.Treatment = t
+			}
+		}
+		if s.Treatment == 0 {
+			return nil, ERROR_UNKNOWN_TREATMENT
+		}
+	}
+	s.Hidden, s.Asleep, s.Idle = d.Hidden, d.Asleep, d.Idle
+	setFloat(&s.Scale, d.Scale)
+	setFloat(&s.Restitution, d.Restitution)
+	setFloat(&s.Cof, d.Cof)
+	setFloat(&s.StaticCof, d.StaticCof)
+
+	// the geometry is already at its scale
+	body.(restorer).restore(s)
+
+	switch {
+	case d.Mass != nil && d.Density != nil && d.MOI != nil && d.Centroid != nil:
+		s.Mass, s.Density, s.MOI, s.Centroid = *d.Mass, *d.Density, *d.MOI, *d.Centroid
+		body.Restore(s)
+	case d.Mass != nil:
+		body.SetMass(*d.Mass)
+	case d.Density != nil:
+		body.SetDensity(*d.Density)
+	}
+	return body, nil
+}
+
+type restorer interface {
+	restore(BodySnapshot)
+}
+
+func setFloat(dst *float64, src *float64) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func encodeState(s BodyState) StateData {
+	return StateData{
+		Pos:        s.Pos,
+		Vel:        s.Vel,
+		Acc:        s.Acc,
+		Angle:      s.Angular.Pos,
+		AngularVel: s.Angular.Vel,
+		AngularAcc: s.Angular.Acc,
+	}
+}
+
+func decodeState(d StateData) BodyState {
+	return BodyState{
+		Pos:     d.Pos,
+		Vel:     d.Vel,
+		Acc:     d.Acc,
+		Angular: Angular{Pos: d.Angle, Vel: d.AngularVel, Acc: d.AngularAcc},
+	}
+}
@@ -11,9 +11,16 @@ type Point struct {
 	mass        float64
 	restitution float64
 	cof         float64
+	staticCof   float64
+	scale       float64
+	asleep      bool
+	idle        uint64 // ticks the body has been still
 	view        interface{}
+	onRefit     func()
 
-	moi float64
+	density  float64
+	moi      float64
+	centroid geom.Vector
 
 	state *BodyState
 	uid   int64
@@ -21,18 +28,15 @@ type Point struct {
 	geometry geometries.Geometry
 }
 
-var uidGen int64 = 0
-
-//func NewPoint(treatment uint, x, y, angle, mass float64) *Point {
+// func NewPoint(treatment uint, x, y, angle, mass float64) *Point {
 func NewPoint() (p *Point) {
-	uidGen++
 	p = &Point{
-		uid:         uidGen,
 		hidden:      false,
 		treatment:   TREATMENT_DYNAMIC,
 		mass:        1.0,
 		restitution: 1.0,
 		cof:         0.8,
+		scale:       1.0,
 		geometry:    geometries.NewPoint(),
 		view:        nil,
 	}
@@ -59,10 +63,10 @@ func (p *Point) Geometry() geometries.Geometry { return p.geometry }
 
 func (p *Point) Cof() float64             { return p.cof }
 func (p *Point) SetCof(v float64)         { p.cof = v }
+func (p *Point) SetStaticCof(v float64)   { p.staticCof = v }
 func (p *Point) Hidden() bool             { return p.hidden }
 func (p *Point) SetHidden(v bool)         { p.hidden = v }
 func (p *Point) Mass() float64            { return p.mass }
-func (p *Point) SetMass(v float64)        { p.mass = v }
 func (p *Point) Restitution() float64     { return p.restitution }
 func (p *Point) SetRestitution(v float64) { p.restitution = v }
 func (p *Point) Treatment() uint          { return p.treatment }
@@ -70,13 +74,42 @@ func (p *Point) SetTreatment(v uint)      { p.treatment = v }
 func (p *Point) View() interface{}        { return p.view }
 func (p *Point) SetView(v interface{})    { p.view = v }
 
+// StaticCof returns the coefficient of static friction.
+// It is never less than Cof, so by default it follows Cof
+func (p *Point) StaticCof() float64 {
+	if p.staticCof < p.cof {
+		return p.cof
+	}
+	return p.staticCof
+}
+
 func (p *Point) SetPosition(x, y float64) { p.state.Pos = geom.Vector{x, y} }
 func (p *Point) SetVelocity(x, y float64) { p.state.Vel = geom.Vector{x, y} }
 
 func (p *Point) State() *BodyState { return p.state }
 func (p *Point) UID() int64        { return p.uid }
+func (p *Point) SetUID(v int64)    { p.uid = v }
 
-func (p *Point) MOI() float64 { return p.moi }
+func (p *Point) MOI() float64          { return p.moi }
+func (p *Point) Centroid() geom.Vector { return p.centroid }
+func (p *Point) Density() float64      { return p.density }
+
+// SetMass keeps the geometry and changes the density
+func (p *Point) SetMass(v float64) {
+	p.mass = v
+	p.Recalc()
+}
+
+// SetDensity changes the mass to fit the area of the geometry.
+// it does nothing for geometries without area
+func (p *Point) SetDensity(v float64) {
+	area := p.geometry.Area()
+	if area == 0 {
+		return
+	}
+	p.mass = v * area
+	p.Recalc()
+}
 
 func (p *Point) Accelerate(acc geom.Vector) {
 	if p.treatment == TREATMENT_DYNAMIC {
@@ -89,6 +122,11 @@ func (p *Point) ApplyForce(force, pp geom.Vector) {
 		return
 	}
 
+	// torque is about the center of mass
+	if !p.centroid.Equals(geom.Vector{}) {
+		pp = pp.Minus(geom.NewTransformAngle(p.state.Angular.Pos).Rotate(p.centroid))
+	}
+
 	if /*pp &&*/ p.moi != 0 {
 		p.state.Angular.Acc -= geom.CrossProduct(pp, force) / p.moi
 	}
@@ -103,4 +141,66 @@ func (p *Point) AABB(angle float64) (aabb geom.AABB) {
 	return
 }
 
-func (p *Point) Recalc() {}
+func (p *Point) Scale() float64 { return p.scale }
+
+// SetScale resizes the geometry, v is relative to its size at scale 1.
+// the density is kept, so the mass follows the area
+func (p *Point) SetScale(v float64) {
+	s, is := p.geometry.(geometries.Scaler)
+	if !is || v <= 0 || v == p.scale {
+		return
+	}
+	s.Scale(v / p.scale)
+	p.scale = v
+	p.Refit()
+}
+
+// Refit updates the mass properties after the geometry was changed in place
+// and wakes the body. the density is kept, so the mass follows the area.
+// The world is told too, it wakes the bodies in contact
+func (p *Point) Refit() {
+	if area := p.geometry.Area(); area != 0 && p.density != 0 {
+		p.mass = p.density * area
+	}
+	p.Recalc()
+	p.Wake()
+	if p.onRefit != nil {
+		p.onRefit()
+	}
+}
+
+// SetOnRefit sets the function Refit calls, the world sets it
+func (p *Point) SetOnRefit(fn func()) { p.onRefit = fn }
+
+func (p *Point) Asleep() bool { return p.asleep }
+
+// Sleep stops a dynamic body until something wakes it
+func (p *Point) Sleep() {
+	if p.treatment != TREATMENT_DYNAMIC {
+		return
+	}
+	p.asleep = true
+	p.state.Vel = geom.Vector{}
+	p.state.Angular.Vel = 0
+}
+
+func (p *Point) Wake() {
+	p.asleep = false
+	p.idle = 0
+}
+
+// IdleTicks is the number of ticks the body has been still,
+// the world counts them to put it to sleep
+func (p *Point) IdleTicks() uint64     { return p.idle }
+func (p *Point) SetIdleTicks(v uint64) { p.idle = v }
+
+// Recalc derives the density, centroid and moment of inertia
+// from the mass and the geometry
+func (p *Point) Recalc() {
+	if area := p.geometry.Area(); area != 0 {
+		p.density = p.mass / area
+	}
+	p.centroid = p.geometry.Centroid()
+	// moment of inertia about the centroid
+	p.moi = p.mass * geometries.CentroidalMOI(p.geometry)
+}
@@ -0,0 +1,22 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+type RoundedPolygon struct {
+	Point
+}
+
+func NewRoundedPolygon(vertices []geom.Vector, radius float64) Body {
+	c := &RoundedPolygon{Point: *NewPoint()}
+	c.geometry = geometries.NewRoundedPolygon(vertices, radius)
+	c.Recalc()
+	return c
+}
+
+// SetVertices changes the core polygon, see ConvexPolygon.SetVertices
+func (this *RoundedPolygon) SetVertices(hull []geom.Vector) error {
+	return this.setVertices(&this.geometry.(*geometries.RoundedPolygon).ConvexPolygon, hull)
+}
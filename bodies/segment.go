@@ -0,0 +1,46 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// Segment bodies are meant for static terrain,
+// so they start with TREATMENT_STATIC
+type Segment struct {
+	Point
+}
+
+func NewSegment(a, b geom.Vector) Body {
+	s := &Segment{Point: *NewPoint()}
+	s.geometry = geometries.NewSegment(a, b)
+	s.treatment = TREATMENT_STATIC
+	s.Recalc()
+	return s
+}
+
+type Chain struct {
+	Point
+}
+
+// NewChain returns a static chain of one-sided segments
+func NewChain(vertices []geom.Vector, loop bool) Body {
+	c := &Chain{Point: *NewPoint()}
+	c.geometry = geometries.NewChain(vertices, loop, true)
+	c.treatment = TREATMENT_STATIC
+	c.Recalc()
+	return c
+}
+
+type Heightfield struct {
+	Point
+}
+
+// NewHeightfield returns static terrain, see geometries.Heightfield
+func NewHeightfield(heights []float64, spacing, scale float64) Body {
+	h := &Heightfield{Point: *NewPoint()}
+	h.geometry = geometries.NewHeightfield(heights, spacing, scale)
+	h.treatment = TREATMENT_STATIC
+	h.Recalc()
+	return h
+}
@@ -0,0 +1,55 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// Slicer is implemented by bodies that can be cut in two
+type Slicer interface {
+	// Slice cuts the body along the line through a and b (world coordinates).
+	// ok is false if the line misses it
+	Slice(a, b geom.Vector) (pieces []Body, ok bool)
+}
+
+// Slice returns two new polygons. Each one keeps the density, so it gets
+// its share of the mass, and moves like the point of the body
+// at its centroid. The body itself is not changed
+func (this *ConvexPolygon) Slice(a, b geom.Vector) (pieces []Body, ok bool) {
+	state := this.state
+	trans := geom.NewTransformAngle(state.Angular.Pos)
+	toLocal := func(pt geom.Vector) geom.Vector {
+		return trans.RotateInv(pt.Minus(state.Pos))
+	}
+
+	polys, centers, ok := this.geometry.(*geometries.ConvexPolygon).Slice(toLocal(a), toLocal(b))
+	if !ok {
+		return nil, false
+	}
+
+	for i, poly := range polys {
+		piece := &ConvexPolygon{Point: *NewPoint()}
+		piece.geometry = poly
+		piece.treatment = this.treatment
+		piece.hidden = this.hidden
+		piece.restitution = this.restitution
+		piece.cof = this.cof
+		piece.staticCof = this.staticCof
+		piece.mass = this.density * poly.Area()
+		piece.Recalc()
+
+		// from the center of mass of the body to the one of the piece
+		r := trans.Rotate(centers[i].Minus(this.centroid))
+
+		s := piece.state
+		s.Pos = state.Pos.Plus(trans.Rotate(centers[i]))
+		s.Vel = state.Vel.Plus(r.Perp(false).Times(state.Angular.Vel))
+		s.Angular.Pos = state.Angular.Pos
+		s.Angular.Vel = state.Angular.Vel
+		s.Old.Pos, s.Old.Vel = s.Pos, s.Vel
+		s.Old.Angular = s.Angular
+
+		pieces = append(pieces, piece)
+	}
+	return pieces, true
+}
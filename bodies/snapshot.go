@@ -0,0 +1,85 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// BodySnapshot is everything about a body that changes while
+// the world runs. The geometry is a copy, made by its registered
+// describer; geometries without one are shared
+type BodySnapshot struct {
+	Geometry geometries.Geometry
+
+	State BodyState // State.Old is nil, see Old
+	Old   BodyState
+
+	Treatment uint
+	Hidden    bool
+	Asleep    bool
+	Idle      uint64
+
+	Mass     float64
+	Density  float64
+	MOI      float64
+	Centroid geom.Vector
+	Scale    float64
+
+	Restitution float64
+	Cof         float64
+	StaticCof   float64
+}
+
+func (p *Point) Snapshot() BodySnapshot {
+	s := BodySnapshot{
+		Geometry:    geometries.CopyGeometry(p.geometry),
+		State:       *p.state,
+		Old:         *p.state.Old,
+		Treatment:   p.treatment,
+		Hidden:      p.hidden,
+		Asleep:      p.asleep,
+		Idle:        p.idle,
+		Mass:        p.mass,
+		Density:     p.density,
+		MOI:         p.moi,
+		Centroid:    p.centroid,
+		Scale:       p.scale,
+		Restitution: p.restitution,
+		Cof:         p.cof,
+		StaticCof:   p.staticCof,
+	}
+	s.State.Old = nil
+	return s
+}
+
+// Restore puts the body back as it was. The body gets a new copy of the
+// geometry and the mass properties are copied, not derived again,
+// so the body is exactly the same and the snapshot can be used again
+func (p *Point) Restore(s BodySnapshot) {
+	if s.Geometry != nil {
+		p.geometry = geometries.CopyGeometry(s.Geometry)
+	}
+	p.restore(s)
+}
+
+// restore copies the snapshot without touching the geometry
+func (p *Point) restore(s BodySnapshot) {
+	old := p.state.Old
+	*p.state = s.State
+	*old = s.Old
+	old.Old = nil
+	p.state.Old = old
+
+	p.treatment = s.Treatment
+	p.hidden = s.Hidden
+	p.asleep = s.Asleep
+	p.idle = s.Idle
+	p.mass = s.Mass
+	p.density = s.Density
+	p.moi = s.MOI
+	p.centroid = s.Centroid
+	p.scale = s.Scale
+	p.restitution = s.Restitution
+	p.cof = s.Cof
+	p.staticCof = s.StaticCof
+}
@@ -0,0 +1,36 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// NewConvexPolygons decomposes a simple polygon (world coordinates)
+// into convex polygon bodies, each one placed at its centroid
+func NewConvexPolygons(outline []geom.Vector) ([]Body, error) {
+	parts, err := geometries.DecomposePolygon(outline)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []Body{}
+	for _, part := range parts {
+		body := NewConvexPolygon(part)
+		center := geometries.PolygonCentroid(part)
+		body.SetPosition(center.X, center.Y)
+		list = append(list, body)
+	}
+	return list, nil
+}
+
+// WorldOutline returns the outline of the body's geometry
+// in world coordinates
+func WorldOutline(body Body, segments int) []geom.Vector {
+	state := body.State()
+	trans := geom.NewTransform(state.Pos, state.Angular.Pos, geom.Vector{})
+	outline := body.Geometry().Outline(segments)
+	for i, v := range outline {
+		outline[i] = trans.Translate(trans.Rotate(v))
+	}
+	return outline
+}
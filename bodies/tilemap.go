@@ -0,0 +1,51 @@
+package bodies
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
+// NewChainsFromTiles merges the tiles (see geometries.TileOutlines) into
+// static looped chains. tile (column, row) is at (column, row) * size
+func NewChainsFromTiles(grid [][]int, size float64) []Body {
+	list := []Body{}
+	for _, loop := range geometries.TileOutlines(grid) {
+		list = append(list, NewChain(scaled(loop, size), true))
+	}
+	return list
+}
+
+// NewPolygonsFromTiles merges the tiles into as few
+// static convex polygons as the decomposition finds
+func NewPolygonsFromTiles(grid [][]int, size float64) ([]Body, error) {
+	loops := [][]geom.Vector{}
+	for _, loop := range geometries.TileOutlines(grid) {
+		loops = append(loops, scaled(loop, size))
+	}
+
+	outlines, err := geometries.FillContours(loops)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []Body{}
+	for _, outline := range outlines {
+		parts, err := NewConvexPolygons(outline)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			part.SetTreatment(TREATMENT_STATIC)
+			list = append(list, part)
+		}
+	}
+	return list, nil
+}
+
+func scaled(points []geom.Vector, factor float64) []geom.Vector {
+	result := make([]geom.Vector, len(points))
+	for i, pt := range points {
+		result[i] = pt.Times(factor)
+	}
+	return result
+}
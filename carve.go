@@ -0,0 +1,85 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	"math"
+)
+
+// how many segments circles are split into when they are carved
+const carveSegments = 32
+
+// Carve cuts the crater (world coordinates) out of every static convex body
+// it touches. the bodies are rebuilt in place from convex pieces which keep
+// the material of the original. it returns the new pieces.
+// a body the crater can't be clipped from is left as it is
+// and the first such error is returned
+func (w *world) Carve(crater []geom.Vector) (added []bodies.Body, err error) {
+	box := craterAABB(crater)
+
+	for _, body := range w.QueryAABB(box) {
+		if body.Treatment() != bodies.TREATMENT_STATIC || !isCarvable(body.Geometry()) {
+			continue
+		}
+
+		outline := bodies.WorldOutline(body, carveSegments)
+		hit, e := geometries.Intersection(outline, crater)
+		if e == nil && len(hit) == 0 {
+			continue
+		}
+		var parts [][]geom.Vector
+		if e == nil {
+			parts, e = geometries.Difference(outline, crater)
+		}
+		if e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+
+		pieces := []bodies.Body{}
+		for _, part := range parts {
+			list, e := bodies.NewConvexPolygons(part)
+			if e != nil {
+				// a sliver that can't be decomposed
+				continue
+			}
+			pieces = append(pieces, list...)
+		}
+
+		w.Remove(body)
+		for _, piece := range pieces {
+			piece.SetTreatment(body.Treatment())
+			piece.SetHidden(body.Hidden())
+			piece.SetRestitution(body.Restitution())
+			piece.SetCof(body.Cof())
+			piece.SetStaticCof(body.StaticCof())
+			piece.SetDensity(body.Density())
+			piece.SetView(body.View())
+			w.Add(piece)
+		}
+		added = append(added, pieces...)
+	}
+	return
+}
+
+// isCarvable checks that the outline is the exact shape
+func isCarvable(g geometries.Geometry) bool {
+	switch g.(type) {
+	case *geometries.ConvexPolygon, *geometries.Rectangle, *geometries.Circle:
+		return true
+	}
+	return false
+}
+
+func craterAABB(crater []geom.Vector) geom.AABB {
+	min := geom.Vector{math.Inf(1), math.Inf(1)}
+	max := geom.Vector{math.Inf(-1), math.Inf(-1)}
+	for _, v := range crater {
+		min.X, min.Y = math.Min(min.X, v.X), math.Min(min.Y, v.Y)
+		max.X, max.Y = math.Max(max.X, v.X), math.Max(max.Y, v.Y)
+	}
+	return geom.NewAABB_byMM(min.X, min.Y, max.X, max.Y)
+}
@@ -0,0 +1,74 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_Carve(t *testing.T) {
+	Convey("Carve", t, func() {
+		world := NewWorldImprovedEuler()
+
+		ground := bodies.NewRectangle(10, 2)
+		ground.SetTreatment(bodies.TREATMENT_STATIC)
+		ground.SetCof(0.3)
+		ball := bodies.NewCircle(1)
+		ball.SetPosition(0, -5)
+		world.Add(ground, ball)
+
+		Convey("should rebuild the terrain without the crater", func() {
+			crater := []geom.Vector{{-1, -2}, {1, -2}, {1, 0}, {-1, 0}}
+			pieces, err := world.Carve(crater)
+			So(err, ShouldBeNil)
+			So(len(pieces), ShouldBeGreaterThan, 0)
+			So(world.Bodies(), ShouldNotContain, ground)
+			So(world.Bodies(), ShouldContain, ball)
+
+			area := 0.0
+			for _, piece := range pieces {
+				area += piece.Geometry().Area()
+				So(piece.Treatment(), ShouldEqual, bodies.TREATMENT_STATIC)
+				So(piece.Cof(), ShouldEqual, 0.3)
+			}
+			So(area, ShouldAlmostEqual, 18, 1e-4)
+
+			So(world.QueryPoint(geom.Vector{0, -0.5}), ShouldBeEmpty)
+			So(world.QueryPoint(geom.Vector{0, 0.5}), ShouldHaveLength, 1)
+			So(math.Abs(world.QueryPoint(geom.Vector{3, -0.5})[0].State().Pos.X), ShouldBeGreaterThan, 1)
+		})
+
+		Convey("should take the carved body out of the broadphase", func() {
+			// the broadphase tracks the bodies added after it
+			world := NewWorldImprovedEuler()
+			world.Add(behaviors.NewSweepPrune(), behaviors.NewBodyCollisionDetection())
+			inside := bodies.NewCircle(0.4)
+			inside.SetPosition(0, -0.5)
+			world.Add(ground, inside)
+
+			crater := []geom.Vector{{-1, -2}, {1, -2}, {1, 0}, {-1, 0}}
+			_, err := world.Carve(crater)
+			So(err, ShouldBeNil)
+
+			found := []behaviors.Collision{}
+			callback := func(data interface{}) { found = append(found, data.([]behaviors.Collision)...) }
+			world.On("collisions:detected", &callback)
+			world.StepTick()
+			world.Off("collisions:detected", &callback)
+
+			// the ball is in the crater, clear of the pieces
+			So(found, ShouldBeEmpty)
+		})
+
+		Convey("should leave the bodies the crater misses", func() {
+			crater := []geom.Vector{{20, 20}, {21, 20}, {21, 21}}
+			pieces, err := world.Carve(crater)
+			So(err, ShouldBeNil)
+			So(pieces, ShouldBeEmpty)
+			So(world.Bodies(), ShouldHaveLength, 2)
+		})
+	})
+}
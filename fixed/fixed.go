@@ -0,0 +1,140 @@
+// Package fixed is a Q32.32 fixed-point backend. Everything here is done
+// with integers, so the results are the same on every architecture,
+// unlike float64 where the compiler may fuse operations and math.Sin
+// may differ between platforms. Lockstep worlds integrate with it and
+// the fixed-body-* behaviors collide circles and polygons with it.
+package fixed
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Fixed is a number with 32 integer and 32 fractional bits
+type Fixed int64
+
+const (
+	FRAC_BITS = 32
+
+	One  Fixed = 1 << FRAC_BITS
+	Half Fixed = One >> 1
+
+	MaxValue Fixed = math.MaxInt64
+	MinValue Fixed = math.MinInt64
+)
+
+func FromInt(i int) Fixed { return Fixed(int64(i) << FRAC_BITS) }
+
+// FromFloat rounds to the nearest fixed-point number.
+// Only use it for input, the conversion is done once and stored
+func FromFloat(f float64) Fixed {
+	switch {
+	case f >= 1<<31:
+		return MaxValue
+	case f <= -(1 << 31):
+		return MinValue
+	}
+	return Fixed(math.Floor(f*float64(One) + 0.5))
+}
+
+// Float converts exactly for values with up to 53 significant bits
+func (a Fixed) Float() float64 { return float64(a) / float64(One) }
+
+// Int truncates toward negative infinity
+func (a Fixed) Int() int { return int(a >> FRAC_BITS) }
+
+func (a Fixed) Add(b Fixed) Fixed { return a + b }
+func (a Fixed) Sub(b Fixed) Fixed { return a - b }
+func (a Fixed) Neg() Fixed        { return -a }
+
+func (a Fixed) Abs() Fixed {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Mul rounds to nearest and saturates on overflow
+func (a Fixed) Mul(b Fixed) Fixed {
+	negative := (a < 0) != (b < 0)
+	hi, lo := bits.Mul64(uint64(a.Abs()), uint64(b.Abs()))
+
+	// shift the 128 bit product right, rounding half up
+	lo, carry := bits.Add64(lo, 1<<(FRAC_BITS-1), 0)
+	hi += carry
+	if hi>>(FRAC_BITS-1) != 0 {
+		return saturate(negative)
+	}
+	r := Fixed(hi<<FRAC_BITS | lo>>FRAC_BITS)
+	if negative {
+		return -r
+	}
+	return r
+}
+
+// Div truncates toward zero and saturates on overflow and division by zero
+func (a Fixed) Div(b Fixed) Fixed {
+	negative := (a < 0) != (b < 0)
+	if b == 0 {
+		return saturate(negative)
+	}
+	n, d := uint64(a.Abs()), uint64(b.Abs())
+	hi, lo := n>>FRAC_BITS, n<<FRAC_BITS
+	if hi >= d {
+		return saturate(negative)
+	}
+	q, _ := bits.Div64(hi, lo, d)
+	if q > math.MaxInt64 {
+		return saturate(negative)
+	}
+	if negative {
+		return -Fixed(q)
+	}
+	return Fixed(q)
+}
+
+// Sqrt rounds down. It returns zero for negative numbers
+func (a Fixed) Sqrt() Fixed {
+	if a <= 0 {
+		return 0
+	}
+	// the result is the integer square root of a << FRAC_BITS.
+	// math.Sqrt is exact in IEEE 754, so the guess is the same
+	// everywhere, and the loops fix it up to the exact floor
+	hi, lo := uint64(a)>>FRAC_BITS, uint64(a)<<FRAC_BITS
+	r := uint64(math.Sqrt(float64(a) * float64(One)))
+	for r > 0 && greater128(r, hi, lo) {
+		r--
+	}
+	for !greater128(r+1, hi, lo) {
+		r++
+	}
+	return Fixed(r)
+}
+
+// greater128 checks if r*r > hi:lo
+func greater128(r, hi, lo uint64) bool {
+	h, l := bits.Mul64(r, r)
+	return h > hi || h == hi && l > lo
+}
+
+func saturate(negative bool) Fixed {
+	if negative {
+		return MinValue
+	}
+	return MaxValue
+}
+
+func Min(a, b Fixed) Fixed {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func Max(a, b Fixed) Fixed {
+	if a > b {
+		return a
+	}
+	return b
+}
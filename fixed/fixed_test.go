@@ -0,0 +1,59 @@
+package fixed
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_Fixed(t *testing.T) {
+	Convey("Fixed", t, func() {
+		Convey("should do arithmetic", func() {
+			a, b := FromFloat(2.5), FromFloat(-1.25)
+			So((a + b).Float(), ShouldEqual, 1.25)
+			So(a.Mul(b).Float(), ShouldEqual, -3.125)
+			So(a.Div(b).Float(), ShouldEqual, -2)
+			So(FromInt(7).Int(), ShouldEqual, 7)
+			So(FromFloat(-0.5).Int(), ShouldEqual, -1)
+		})
+
+		Convey("should saturate instead of overflowing", func() {
+			big := FromInt(1 << 30)
+			So(big.Mul(big), ShouldEqual, MaxValue)
+			So(big.Mul(-big), ShouldEqual, MinValue)
+			So(One.Div(0), ShouldEqual, MaxValue)
+			So(big.Div(FromFloat(1e-6)), ShouldEqual, MaxValue)
+		})
+
+		Convey("should take exact square roots", func() {
+			So(FromInt(16).Sqrt(), ShouldEqual, FromInt(4))
+			So(FromFloat(0.25).Sqrt(), ShouldEqual, Half)
+			So(FromInt(2).Sqrt().Float(), ShouldAlmostEqual, math.Sqrt2, 1e-9)
+			So(FromInt(-1).Sqrt(), ShouldEqual, 0)
+		})
+
+		Convey("should be close to the float trig", func() {
+			for x := -10.0; x <= 10; x += 0.137 {
+				f := FromFloat(x)
+				So(Sin(f).Float(), ShouldAlmostEqual, math.Sin(x), 1e-8)
+				So(Cos(f).Float(), ShouldAlmostEqual, math.Cos(x), 1e-8)
+				y := FromFloat(math.Sin(x) * 3)
+				c := FromFloat(math.Cos(x) * 3)
+				So(Atan2(y, c).Float(), ShouldAlmostEqual, math.Atan2(y.Float(), c.Float()), 1e-8)
+			}
+		})
+
+		Convey("should rotate vectors", func() {
+			t := NewTransformAngle(HalfPi)
+			v := t.Rotate(Vector{One, 0})
+			So(v.X.Float(), ShouldAlmostEqual, 0, 1e-9)
+			So(v.Y.Float(), ShouldAlmostEqual, 1, 1e-9)
+			back := t.RotateInv(v)
+			So(back.X.Float(), ShouldAlmostEqual, 1, 1e-9)
+
+			So(Vector{FromInt(3), FromInt(4)}.Magnitude(), ShouldEqual, FromInt(5))
+			So(AABB{0, 0, One, One}.Overlaps(AABB{FromInt(2), 0, One, One}), ShouldBeTrue)
+			So(AABB{0, 0, One, One}.Contains(Vector{FromInt(2), 0}), ShouldBeFalse)
+		})
+	})
+}
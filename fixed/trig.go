@@ -0,0 +1,115 @@
+package fixed
+
+// the constants are rounded to the nearest Q32.32
+const (
+	Pi        Fixed = 13493037705 // 3.14159265358979...
+	TwoPi     Fixed = 26986075409
+	HalfPi    Fixed = 6746518852
+	QuarterPi Fixed = 3373259426
+)
+
+// Sin uses a Taylor series on [-Pi/4, Pi/4], the error is below 1e-9
+func Sin(a Fixed) Fixed {
+	x, quadrant := reduceAngle(a)
+	switch quadrant {
+	case 0:
+		return sinSeries(x)
+	case 1:
+		return cosSeries(x)
+	case 2:
+		return -sinSeries(x)
+	default:
+		return -cosSeries(x)
+	}
+}
+
+func Cos(a Fixed) Fixed {
+	x, quadrant := reduceAngle(a)
+	switch quadrant {
+	case 0:
+		return cosSeries(x)
+	case 1:
+		return -sinSeries(x)
+	case 2:
+		return -cosSeries(x)
+	default:
+		return sinSeries(x)
+	}
+}
+
+// reduceAngle returns x in [-Pi/4, Pi/4] and the quadrant q,
+// so that a = x + q * Pi/2 (mod 2*Pi)
+func reduceAngle(a Fixed) (x Fixed, quadrant int) {
+	a %= TwoPi
+	if a < 0 {
+		a += TwoPi
+	}
+	quadrant = int((a + QuarterPi) / HalfPi)
+	x = a - Fixed(quadrant)*HalfPi
+	return x, quadrant % 4
+}
+
+func sinSeries(x Fixed) Fixed {
+	// x - x^3/3! + x^5/5! - ...
+	x2 := x.Mul(x)
+	term, sum := x, x
+	for n := 2; n <= 12; n += 2 {
+		term = -term.Mul(x2) / Fixed(n*(n+1))
+		sum += term
+	}
+	return sum
+}
+
+func cosSeries(x Fixed) Fixed {
+	// 1 - x^2/2! + x^4/4! - ...
+	x2 := x.Mul(x)
+	term, sum := One, One
+	for n := 1; n <= 12; n += 2 {
+		term = -term.Mul(x2) / Fixed(n*(n+1))
+		sum += term
+	}
+	return sum
+}
+
+// Atan2 returns the angle of (x, y) in [-Pi, Pi]
+func Atan2(y, x Fixed) Fixed {
+	switch {
+	case x == 0 && y == 0:
+		return 0
+	case x == 0 && y > 0:
+		return HalfPi
+	case x == 0:
+		return -HalfPi
+	}
+
+	ax, ay := x.Abs(), y.Abs()
+	var r Fixed
+	if ay <= ax {
+		r = atan(ay.Div(ax))
+	} else {
+		r = HalfPi - atan(ax.Div(ay))
+	}
+
+	if x < 0 {
+		r = Pi - r
+	}
+	if y < 0 {
+		r = -r
+	}
+	return r
+}
+
+// atan works on [0, 1]. It halves the angle once with
+// atan(t) = 2 * atan(t / (1 + sqrt(1 + t^2))) so the series converges fast
+func atan(t Fixed) Fixed {
+	t = t.Div(One + (One + t.Mul(t)).Sqrt())
+
+	// t - t^3/3 + t^5/5 - ...
+	t2 := t.Mul(t)
+	power, sum := t, t
+	for n := 3; n <= 25; n += 2 {
+		power = -power.Mul(t2)
+		sum += power / Fixed(n)
+	}
+	return 2 * sum
+}
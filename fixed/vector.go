@@ -0,0 +1,109 @@
+package fixed
+
+import (
+	"github.com/oniproject/physics.go/geom"
+)
+
+type Vector struct {
+	X, Y Fixed
+}
+
+func VectorFrom(v geom.Vector) Vector { return Vector{FromFloat(v.X), FromFloat(v.Y)} }
+func (p Vector) Float() geom.Vector   { return geom.Vector{p.X.Float(), p.Y.Float()} }
+
+func (p Vector) Plus(q Vector) Vector  { return Vector{p.X + q.X, p.Y + q.Y} }
+func (p Vector) Minus(q Vector) Vector { return Vector{p.X - q.X, p.Y - q.Y} }
+func (p Vector) Times(s Fixed) Vector  { return Vector{p.X.Mul(s), p.Y.Mul(s)} }
+
+func (p Vector) Dot(q Vector) Fixed   { return p.X.Mul(q.X) + p.Y.Mul(q.Y) }
+func (p Vector) Cross(q Vector) Fixed { return p.X.Mul(q.Y) - p.Y.Mul(q.X) }
+
+// Perp is the same as geom.Vector.Perp
+func (p Vector) Perp(ccw bool) Vector {
+	if ccw {
+		return Vector{p.Y, -p.X}
+	}
+	return Vector{-p.Y, p.X}
+}
+
+func (p Vector) MagnitudeSquared() Fixed { return p.Dot(p) }
+func (p Vector) Magnitude() Fixed        { return p.MagnitudeSquared().Sqrt() }
+
+func (p Vector) Unit() Vector {
+	m := p.Magnitude()
+	if m == 0 {
+		return Vector{}
+	}
+	return Vector{p.X.Div(m), p.Y.Div(m)}
+}
+
+func (p Vector) Angle() Fixed { return Atan2(p.Y, p.X) }
+
+// AABB is the same as geom.AABB
+type AABB struct {
+	X, Y   Fixed
+	HW, HH Fixed
+}
+
+func AABBFrom(box geom.AABB) AABB {
+	return AABB{FromFloat(box.X), FromFloat(box.Y), FromFloat(box.HW), FromFloat(box.HH)}
+}
+
+func (a AABB) Float() geom.AABB {
+	return geom.AABB{X: a.X.Float(), Y: a.Y.Float(), HW: a.HW.Float(), HH: a.HH.Float()}
+}
+
+func (a AABB) Min() Vector { return Vector{a.X - a.HW, a.Y - a.HH} }
+func (a AABB) Max() Vector { return Vector{a.X + a.HW, a.Y + a.HH} }
+
+func (a AABB) Contains(pt Vector) bool {
+	return (pt.X-a.X).Abs() <= a.HW && (pt.Y-a.Y).Abs() <= a.HH
+}
+
+func (a AABB) Overlaps(b AABB) bool {
+	return (a.X-b.X).Abs() <= a.HW+b.HW && (a.Y-b.Y).Abs() <= a.HH+b.HH
+}
+
+// Transform is the same as geom.Transform, with the fixed-point trig
+type Transform struct {
+	Vect   Vector
+	Angle  Fixed
+	Origin Vector
+
+	CosA, SinA Fixed
+}
+
+func NewTransformAngle(angle Fixed) *Transform {
+	return NewTransform(Vector{}, angle, Vector{})
+}
+
+func NewTransform(vect Vector, angle Fixed, origin Vector) *Transform {
+	t := &Transform{Vect: vect}
+	t.SetRotation(angle, origin)
+	return t
+}
+
+func (t *Transform) SetRotation(angle Fixed, origin Vector) {
+	t.Angle = angle
+	t.CosA = Cos(angle)
+	t.SinA = Sin(angle)
+	t.Origin = origin
+}
+
+func (t *Transform) Translate(vect Vector) Vector { return vect.Plus(t.Vect) }
+
+func (t *Transform) Rotate(vect Vector) Vector {
+	v := vect.Minus(t.Origin)
+	return Vector{
+		X: v.X.Mul(t.CosA) - v.Y.Mul(t.SinA) + t.Origin.X,
+		Y: v.X.Mul(t.SinA) + v.Y.Mul(t.CosA) + t.Origin.Y,
+	}
+}
+
+func (t *Transform) RotateInv(vect Vector) Vector {
+	v := vect.Minus(t.Origin)
+	return Vector{
+		X: v.X.Mul(t.CosA) + v.Y.Mul(t.SinA) + t.Origin.X,
+		Y: -v.X.Mul(t.SinA) + v.Y.Mul(t.CosA) + t.Origin.Y,
+	}
+}
@@ -5,10 +5,10 @@ import (
 )
 
 type AABB struct {
-	X  float64 // the x coord of the center point
-	Y  float64 // the y coord of the center point
-	HW float64 // the half-width
-	HH float64 // the half-height
+	X  float64 `json:"x"`  // the x coord of the center point
+	Y  float64 `json:"y"`  // the y coord of the center point
+	HW float64 `json:"hw"` // the half-width
+	HH float64 `json:"hh"` // the half-height
 }
 
 /* Physics.aabb( minX, minY, maxX, maxY ) -> Object
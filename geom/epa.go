@@ -0,0 +1,93 @@
+package geom
+
+import (
+	"math"
+)
+
+const (
+	epaAccuracy      = 0.0001
+	epaMaxIterations = 100
+)
+
+type EPAresult struct {
+	Norm       Vector  // the direction to push B out of A
+	Depth      float64 // how deep A and B overlap
+	A, B       Vector  // the deepest points on A and B
+	Iterations int
+}
+
+// EPA runs the Expanding Polytope Algorithm starting from the simplex
+// GJK found around the origin, and finds the penetration depth and normal.
+func EPA(support func(Vector) VectorABP, simplex []VectorABP) (result EPAresult) {
+	poly := append([]VectorABP{}, simplex...)
+
+	// GJK stopped on a point or a line (the shapes are just touching)...
+	// blow it up into a triangle
+	if len(poly) == 1 {
+		return EPAresult{Norm: Vector{1, 0}, A: poly[0].A, B: poly[0].B}
+	}
+	if len(poly) == 2 {
+		e := poly[1].PT.Minus(poly[0].PT)
+		if e.Equals(Vector{}) {
+			return EPAresult{Norm: Vector{1, 0}, A: poly[0].A, B: poly[0].B}
+		}
+		p := support(e.Perp(false))
+		if math.Abs(CrossProduct(e, p.PT.Minus(poly[0].PT))) < epaAccuracy {
+			p = support(e.Perp(true))
+		}
+		poly = append(poly, p)
+	}
+
+	// keep the polytope counter-clockwise,
+	// so (e.Y, -e.X) is always the outward normal of edge e
+	if CrossProduct(poly[1].PT.Minus(poly[0].PT), poly[2].PT.Minus(poly[0].PT)) < 0 {
+		poly[1], poly[2] = poly[2], poly[1]
+	}
+
+	index := 0
+	for {
+		result.Iterations++
+
+		// find the edge closest to the origin
+		index = -1
+		result.Depth = math.Inf(1)
+		for i := range poly {
+			e := poly[(i+1)%len(poly)].PT.Minus(poly[i].PT)
+			if e.Equals(Vector{}) {
+				continue
+			}
+			n := Vector{e.Y, -e.X}.Unit()
+			if d := DotProduct(n, poly[i].PT); d < result.Depth {
+				index, result.Depth, result.Norm = i, d, n
+			}
+		}
+
+		if index < 0 {
+			// it's flat... nothing to expand
+			return EPAresult{Norm: Vector{1, 0}, A: poly[0].A, B: poly[0].B}
+		}
+
+		if result.Iterations >= epaMaxIterations {
+			break
+		}
+
+		// expand the polytope towards that edge,
+		// unless it's already on the boundary of the Minkowski Difference
+		p := support(result.Norm)
+		if DotProduct(p.PT, result.Norm)-result.Depth < epaAccuracy {
+			break
+		}
+
+		poly = append(poly, VectorABP{})
+		copy(poly[index+2:], poly[index+1:])
+		poly[index+1] = p
+	}
+
+	// the deepest points are where the origin projects onto that edge
+	closest, _ := closestOnSegment(poly[index], poly[(index+1)%len(poly)])
+	result.A, result.B = closest.A, closest.B
+	if result.Depth < 0 {
+		result.Depth = 0
+	}
+	return
+}
@@ -1,7 +1,6 @@
 package geom
 
 import (
-	"log"
 	"math"
 )
 
@@ -14,51 +13,71 @@ type VectorABP struct {
 	A, B, PT Vector
 }
 
-func getNextSearchDir(ptA, ptB Vector) Vector {
-	ABdotB := ptB.MagnitudeSquared() - DotProduct(ptB, ptA)
-	if ABdotB < 0 {
-		return ptB.Times(-1)
+// lerpABP returns the point at t on the segment from p to q
+func lerpABP(p, q VectorABP, t float64) VectorABP {
+	return VectorABP{
+		A:  p.A.Plus(q.A.Minus(p.A).Times(t)),
+		B:  p.B.Plus(q.B.Minus(p.B).Times(t)),
+		PT: p.PT.Plus(q.PT.Minus(p.PT).Times(t)),
 	}
-
-	ABdotA := DotProduct(ptA, ptB) - ptA.MagnitudeSquared()
-	if ABdotA < 0 {
-		return ptA.Times(-1)
-	}
-
-	dir := ptB.Minus(ptA)
-	return dir.Perp(CrossProduct(ptA, dir) > 0)
 }
 
-func getClosestPoints(simplex []VectorABP) (a, b Vector) {
-	last := simplex[len(simplex)-2]
-	prev := simplex[len(simplex)-3]
-
-	A := last.PT
-	L := prev.PT.Minus(a)
+// closestOnSegment finds the point of the segment pq closest to the origin.
+// it returns the reduced simplex (the feature the point lies on)
+func closestOnSegment(p, q VectorABP) (VectorABP, []VectorABP) {
+	L := q.PT.Minus(p.PT)
 
 	if L.Equals(Vector{}) {
 		// oh.. it's a zero vector.
-		// So A and B are both the closest.
 		// just use one of them
-		return last.A, last.B
+		return p, []VectorABP{p}
 	}
 
-	lambdaB := -DotProduct(L, A) / L.MagnitudeSquared()
-	lambdaA := 1 - lambdaB
+	t := -DotProduct(L, p.PT) / L.MagnitudeSquared()
 
-	if lambdaA <= 0 {
-		// woops.. that means the closest simplex point
-		// isnt on teh line its point B itself
-		return prev.A, prev.B
-	} else if lambdaB <= 0 {
+	switch {
+	case t <= 0:
+		// the closest point isnt on the line its p itself
+		return p, []VectorABP{p}
+	case t >= 1:
 		// vice versa
-		return last.A, last.B
+		return q, []VectorABP{q}
 	}
 
-	a = last.A.Times(lambdaA).Plus(prev.A.Times(lambdaB))
-	b = last.B.Times(lambdaA).Plus(prev.B.Times(lambdaB))
+	return lerpABP(p, q, t), []VectorABP{p, q}
+}
 
-	return
+// closestOnSimplex finds the point of the simplex closest to the origin.
+// if the simplex is a triangle that contains the origin, inside is true.
+func closestOnSimplex(simplex []VectorABP) (closest VectorABP, reduced []VectorABP, inside bool) {
+	switch len(simplex) {
+	case 1:
+		return simplex[0], simplex, false
+	case 2:
+		closest, reduced = closestOnSegment(simplex[0], simplex[1])
+		return closest, reduced, false
+	}
+
+	a, b, c := simplex[0].PT, simplex[1].PT, simplex[2].PT
+
+	// Since we're in 2D we can be clever...
+	// the origin is inside if it's on the same side of all three edges
+	s1 := CrossProduct(b.Minus(a), a.Times(-1))
+	s2 := CrossProduct(c.Minus(b), b.Times(-1))
+	s3 := CrossProduct(a.Minus(c), c.Times(-1))
+	if (s1 >= 0 && s2 >= 0 && s3 >= 0) || (s1 <= 0 && s2 <= 0 && s3 <= 0) {
+		return closest, simplex, true
+	}
+
+	// otherwise it's closest to one of the edges
+	best := math.Inf(1)
+	for i := range simplex {
+		pt, feature := closestOnSegment(simplex[i], simplex[(i+1)%3])
+		if d := pt.PT.MagnitudeSquared(); d < best {
+			best, closest, reduced = d, pt, feature
+		}
+	}
+	return closest, reduced, false
 }
 
 type GJKresult struct {
@@ -69,16 +88,20 @@ type GJKresult struct {
 	A, B       Vector
 }
 
+// GJK runs the Gilbert–Johnson–Keerthi algorithm on the Minkowski difference
+// described by support.
+//
+// If the shapes overlap, Overlap is true and Simplex holds the points
+// that enclose the origin (usually a triangle) which can be fed to EPA.
+// Otherwise, unless checkOverlapOnly is set, Distance holds the distance
+// between the shapes and A, B the closest points on them.
 func GJK(support func(Vector) VectorABP, dir Vector, checkOverlapOnly bool) (result GJKresult) {
-	noOverlap := false
+	if dir.Equals(Vector{}) {
+		dir = Vector{1, 0}
+	}
 
 	// get the first Minkowski Difference point
-	tmp := support(dir)
-	result.Simplex = append(result.Simplex, tmp)
-	last := tmp.PT
-	lastlast := last
-
-	dir.Times(-1)
+	result.Simplex = append(result.Simplex, support(dir))
 
 	for {
 		result.Iterations++
@@ -90,123 +113,34 @@ func GJK(support func(Vector) VectorABP, dir Vector, checkOverlapOnly bool) (res
 			return
 		}
 
-		lastlast = last
+		closest, reduced, inside := closestOnSimplex(result.Simplex)
+		result.Simplex = reduced
 
-		tmp = support(dir)
-		result.Simplex = append(result.Simplex, tmp)
-		last = tmp.PT
-
-		log.Println(result.Simplex)
-
-		if last.Equals(Vector{}) {
-			// we happened to pick the origin as a support point... lucky.
+		if inside || closest.PT.MagnitudeSquared() < gjkAccuracy*gjkAccuracy {
+			// we have enclosed the origin! (or we are touching it)
 			result.Overlap = true
-			break
+			return
 		}
 
-		// check if the last point we added actually passed the origin
+		// look towards the origin from the closest point
+		dir = closest.PT.Times(-1)
+		last := support(dir)
 
-		if !noOverlap && DotProduct(last, dir) <= 0.0 {
+		if DotProduct(last.PT, dir) < 0 && checkOverlapOnly {
 			// if the point added last was not past the origin in the direction of d
 			// then the Minkowski difference cannot possibly contain the origin since
 			// the last point added is on the edge of the Minkowski Difference
-
-			// if we just need the overlap...
-			if checkOverlapOnly {
-				break
-			}
-
-			noOverlap = true
+			return
 		}
 
-		if len(result.Simplex) == 2 { // if its a line
-			// otherwise we need to determine if the origin is in
-			// the current simplex and act accordingly
-			dir = getNextSearchDir(last, lastlast)
-			// continue...
-		} else if noOverlap { // if its a triangle... and we're looking for the distance
-			// if we know there isnt any overlap and
-			// we're just trying to find the distance...
-			// make sure we're getting closer to the origin
-			dir = dir.Unit()
-			d1 := DotProduct(lastlast, dir)
-			d2 := DotProduct(last, dir)
-			if math.Abs(d1-d2) < gjkAccuracy {
-				result.Distance = -d1
-				break
-			}
-
-			// if we are still getting closer then only keep
-			// the points in the simplex that are closest to
-			// the origin (we already know that last is closer than the previous two)
-			// the norm is the same as distance(origin, a)
-			// use norm squared to avoid the sqrt operations
-			if lastlast.MagnitudeSquared() < result.Simplex[0].PT.MagnitudeSquared() {
-				//rm first
-				result.Simplex = result.Simplex[1:]
-			} else {
-				// rm second
-				result.Simplex = append(result.Simplex[:1], result.Simplex[2:]...)
-			}
-			dir = getNextSearchDir(result.Simplex[1].PT, result.Simplex[0].PT)
-			// continue...
-		} else { // if its a triangle
-			ab := lastlast.Minus(last)
-			ac := result.Simplex[0].PT.Minus(last)
-
-			// here normally people think about thes as getting outward facing
-			// normals and checking dot products.
-			// Since we're in 2D we can be clever...
-			sign := CrossProduct(ab, ac) > 0
-			sign1 := CrossProduct(last, ab) > 0
-			sign2 := CrossProduct(ac, last) > 0
-
-			switch {
-			case (sign || sign1) && !(sign && sign1):
-				// ok... so there's and XOR here... dont freak out
-				// remember last = A = -AO
-				// if AB cross AC and AO cross AB have the same sign
-				// then the origin is along the outward facing normal of AB
-				// so if AB cross AC ang A cross AB have _different_ (XOR) signs
-				// then this is also the case... so we proceed...
-
-				// point C is dead to us now...
-				result.Simplex = result.Simplex[1:]
-
-				// if we haven't deduced that we've enclosed the origin
-				// then we know which way to look...
-				// morph the ab vector into its outward facing normal
-
-				ab = ab.Perp(!sign)
-
-				dir, ab = ab, dir
-
-				// continue...
-
-			// if we get to this if, then it means we can continue to look along
-			// the other outward normal direcion (ACperp)
-			// if we dont see the origin... then we must have it enclosed
-			case (sign || sign2) && !(sign && sign2):
-				// then the origin it along the outward facing normal
-				// of AC; (ACperp)
-
-				// point B is dead to us now...
-				result.Simplex = append(result.Simplex[:1], result.Simplex[2:]...)
-
-				ac = ac.Perp(sign)
-				dir, ab = ab, dir
-
-				/// continue...
-			default:
-				// we have enclosed the origin!
-				result.Overlap = true
-				break
-			}
+		// make sure we're getting closer to the origin
+		distance := closest.PT.Magnitude()
+		if distance-DotProduct(last.PT, closest.PT)/distance < gjkAccuracy {
+			result.Distance = distance
+			result.A, result.B = closest.A, closest.B
+			return
 		}
-	}
 
-	if result.Distance != 0 {
-		result.A, result.B = getClosestPoints(result.Simplex)
+		result.Simplex = append(result.Simplex, last)
 	}
-	return
 }
@@ -0,0 +1,64 @@
+package geom
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func squareSupport(center Vector, half float64) func(Vector) Vector {
+	return func(dir Vector) Vector {
+		pt := center
+		if dir.X >= 0 {
+			pt.X += half
+		} else {
+			pt.X -= half
+		}
+		if dir.Y >= 0 {
+			pt.Y += half
+		} else {
+			pt.Y -= half
+		}
+		return pt
+	}
+}
+
+func minkowski(a, b func(Vector) Vector) func(Vector) VectorABP {
+	return func(dir Vector) VectorABP {
+		vA, vB := a(dir), b(dir.Times(-1))
+		return VectorABP{A: vA, B: vB, PT: vA.Minus(vB)}
+	}
+}
+
+func Test_GJK(t *testing.T) {
+	Convey("test GJK", t, func() {
+		a := squareSupport(Vector{0, 0}, 1)
+
+		Convey("should find overlapping shapes", func() {
+			support := minkowski(a, squareSupport(Vector{1.5, 0.5}, 1))
+			result := GJK(support, Vector{1, 0}, true)
+			So(result.Overlap, ShouldBeTrue)
+
+			Convey("and EPA should find the penetration", func() {
+				epa := EPA(support, result.Simplex)
+				So(epa.Depth, ShouldAlmostEqual, 0.5, 1e-9)
+				So(epa.Norm.X, ShouldAlmostEqual, 1, 1e-9)
+				So(epa.Norm.Y, ShouldAlmostEqual, 0, 1e-9)
+			})
+		})
+
+		Convey("should not find separated shapes", func() {
+			support := minkowski(a, squareSupport(Vector{5, 0}, 1))
+			result := GJK(support, Vector{1, 0}, true)
+			So(result.Overlap, ShouldBeFalse)
+		})
+
+		Convey("should find the distance between separated shapes", func() {
+			support := minkowski(a, squareSupport(Vector{5, 1}, 1))
+			result := GJK(support, Vector{1, 0}, false)
+			So(result.Overlap, ShouldBeFalse)
+			So(result.Distance, ShouldAlmostEqual, 3, 1e-9)
+			So(result.A.X, ShouldAlmostEqual, 1, 1e-9)
+			So(result.B.X, ShouldAlmostEqual, 4, 1e-9)
+		})
+	})
+}
@@ -5,7 +5,8 @@ import (
 )
 
 type Vector struct {
-	X, Y float64
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 func (p *Vector) Hashcode() (hash uint64) {
@@ -0,0 +1,110 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// Capsule is a segment from (-HalfLength, 0) to (HalfLength, 0)
+// grown by Radius. The segment is the core and the radius is the margin
+type Capsule struct {
+	HalfLength, Radius float64
+}
+
+func NewCapsule(halfLength, radius float64) Geometry {
+	return &Capsule{halfLength, radius}
+}
+
+func (this *Capsule) AABB(angle float64) geom.AABB {
+	trans := geom.NewTransformAngle(angle)
+	end := trans.Rotate(geom.Vector{this.HalfLength, 0})
+	hw := math.Abs(end.X) + this.Radius
+	hh := math.Abs(end.Y) + this.Radius
+	return geom.NewAABB_byMM(-hw, -hh, hw, hh)
+}
+
+// end returns the end of the segment farthest in the direction
+func (this *Capsule) end(dir geom.Vector) geom.Vector {
+	if dir.X < 0 {
+		return geom.Vector{-this.HalfLength, 0}
+	}
+	return geom.Vector{this.HalfLength, 0}
+}
+
+func (this *Capsule) FarthestHullPoint(dir geom.Vector) geom.Vector {
+	return this.FarthestCorePoint(dir, 0)
+}
+
+func (this *Capsule) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
+	end := this.end(dir)
+	if dir.Equals(geom.Vector{}) {
+		return end
+	}
+	return end.Plus(dir.Unit().Times(this.Radius - margin))
+}
+
+func (this *Capsule) Margin() float64 { return this.Radius }
+
+func (this *Capsule) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	hl, r := this.HalfLength, this.Radius
+
+	// starts inside
+	a, b := geom.Vector{-hl, 0}, geom.Vector{hl, 0}
+	if NearestPointOnLine(from, a, b).DistanceFromSquared(from) < r*r {
+		return hit, false
+	}
+
+	box := []geom.Vector{{hl, r}, {hl, -r}, {-hl, -r}, {-hl, r}}
+	hit, ok = RayCastPolygon(box, from, to)
+
+	for _, center := range []geom.Vector{a, b} {
+		h, hitEnd := RayCastCircle(r, from.Minus(center), to.Minus(center))
+		if hitEnd && (!ok || h.Fraction < hit.Fraction) {
+			h.Point = h.Point.Plus(center)
+			hit, ok = h, true
+		}
+	}
+	return
+}
+
+// capsuleMOI is the moment of inertia for a unit mass:
+// a box and two half circles at its ends
+func capsuleMOI(hl, r float64) float64 {
+	boxArea := 4 * hl * r
+	circleArea := math.Pi * r * r
+	area := boxArea + circleArea
+	if area == 0 {
+		return 0
+	}
+
+	box := (4*hl*hl + 4*r*r) / 12.0
+	circle := r*r/2.0 + hl*hl + 8*hl*r/(3*math.Pi)
+
+	return (boxArea*box + circleArea*circle) / area
+}
+
+func (this *Capsule) Area() float64 {
+	return 4*this.HalfLength*this.Radius + math.Pi*this.Radius*this.Radius
+}
+func (this *Capsule) Centroid() geom.Vector { return geom.Vector{} }
+
+func (this *Capsule) ContainsPoint(local geom.Vector) bool {
+	a, b := geom.Vector{-this.HalfLength, 0}, geom.Vector{this.HalfLength, 0}
+	return NearestPointOnLine(local, a, b).DistanceFromSquared(local) <= this.Radius*this.Radius
+}
+
+// Outline gives each half circle half of the segments
+func (this *Capsule) Outline(segments int) []geom.Vector {
+	n := segments / 2
+	if n < 1 {
+		n = 1
+	}
+	hl, r := this.HalfLength, this.Radius
+	points := arc(geom.Vector{hl, 0}, r, -math.Pi/2, math.Pi/2, n)
+	return append(points, arc(geom.Vector{-hl, 0}, r, math.Pi/2, 3*math.Pi/2, n)...)
+}
+
+func (this *Capsule) Scale(factor float64) {
+	this.HalfLength *= factor
+	this.Radius *= factor
+}
@@ -0,0 +1,50 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_Capsule(t *testing.T) {
+	Convey("test Capsule", t, func() {
+		c := NewCapsule(2, 1).(*Capsule)
+
+		Convey("aabb", func() {
+			So(c.AABB(0), ShouldResemble, geom.AABB{HW: 3, HH: 1})
+
+			aabb := c.AABB(math.Pi / 2)
+			So(aabb.HW, ShouldAlmostEqual, 1)
+			So(aabb.HH, ShouldAlmostEqual, 3)
+		})
+
+		Convey("FarthestHullPoint", func() {
+			So(c.FarthestHullPoint(geom.Vector{1, 0}), ShouldResemble, geom.Vector{3, 0})
+			So(c.FarthestHullPoint(geom.Vector{0, -1}), ShouldResemble, geom.Vector{2, -1})
+			So(c.FarthestHullPoint(geom.Vector{-1, 0}), ShouldResemble, geom.Vector{-3, 0})
+		})
+
+		Convey("FarthestCorePoint", func() {
+			So(c.FarthestCorePoint(geom.Vector{1, 0}, 1), ShouldResemble, geom.Vector{2, 0})
+			So(c.FarthestCorePoint(geom.Vector{-1, 1}, 1), ShouldResemble, geom.Vector{-2, 0})
+		})
+
+		Convey("mass properties", func() {
+			So(c.Area(), ShouldAlmostEqual, 8+math.Pi)
+			// without a segment it's a circle
+			So(GeometryMOI(NewCapsule(0, 3)), ShouldAlmostEqual, GeometryMOI(NewCircle(3)))
+		})
+
+		Convey("RayCast", func() {
+			hit, ok := c.RayCast(geom.Vector{10, 0}, geom.Vector{0, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Point.X, ShouldAlmostEqual, 3)
+
+			hit, ok = c.RayCast(geom.Vector{1, 10}, geom.Vector{1, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Point.Y, ShouldAlmostEqual, 1)
+			So(hit.Norm, ShouldResemble, geom.Vector{0, 1})
+		})
+	})
+}
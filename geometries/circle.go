@@ -2,6 +2,7 @@ package geometries
 
 import (
 	"github.com/oniproject/physics.go/geom"
+	"math"
 )
 
 type Circle struct {
@@ -24,3 +25,23 @@ func (this *Circle) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vect
 	n := dir.Unit()
 	return n.Times(this.Radius - margin)
 }
+
+func (this *Circle) Margin() float64 { return this.Radius }
+
+func (this *Circle) Area() float64         { return math.Pi * this.Radius * this.Radius }
+func (this *Circle) Centroid() geom.Vector { return geom.Vector{} }
+
+func (this *Circle) ContainsPoint(local geom.Vector) bool {
+	return local.MagnitudeSquared() <= this.Radius*this.Radius
+}
+
+// Outline approximates the circle with a regular polygon
+func (this *Circle) Outline(segments int) []geom.Vector {
+	if segments < 3 {
+		segments = 3
+	}
+	points := arc(geom.Vector{}, this.Radius, 0, 2*math.Pi, segments)
+	return points[:segments]
+}
+
+func (this *Circle) Scale(factor float64) { this.Radius *= factor }
@@ -0,0 +1,387 @@
+package geometries
+
+import (
+	"errors"
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+var ERROR_CLIP_DEGENERATE = errors.New("Error: The polygons touch in a way that can't be clipped.")
+
+const (
+	CLIP_INTERSECTION = iota
+	CLIP_UNION
+	CLIP_DIFFERENCE
+)
+
+// how far a vertex is nudged when it sits on the other polygon's boundary
+var clipPerturbation = 1e-7
+
+// how many times the polygon is nudged before giving up
+const clipRetries = 10
+
+// Intersection returns the parts covered by both polygons
+func Intersection(a, b []geom.Vector) ([][]geom.Vector, error) {
+	return Clip(a, b, CLIP_INTERSECTION)
+}
+
+// Union returns the outlines of the area covered by either polygon.
+// holes are filled
+func Union(a, b []geom.Vector) ([][]geom.Vector, error) { return Clip(a, b, CLIP_UNION) }
+
+// Difference returns the parts of a not covered by b
+func Difference(a, b []geom.Vector) ([][]geom.Vector, error) {
+	return Clip(a, b, CLIP_DIFFERENCE)
+}
+
+// Clip runs a boolean operation on two simple polygons (Greiner-Hormann).
+// the results are simple polygons, wound counter-clockwise.
+// ERROR_CLIP_DEGENERATE is returned when the polygons keep touching
+// at vertices or along edges however they are nudged
+func Clip(a, b []geom.Vector, op int) ([][]geom.Vector, error) {
+	a, b = cleanPolygon(a), cleanPolygon(b)
+	if len(a) < 3 || len(b) < 3 {
+		return clipDegenerate(a, b, op), nil
+	}
+
+	b = append([]geom.Vector{}, b...)
+	for try := 0; ; try++ {
+		subject, clip, found, degenerate := clipIntersect(a, b)
+		if degenerate {
+			if try == clipRetries {
+				return nil, ERROR_CLIP_DEGENERATE
+			}
+			// a vertex on an edge or overlapping edges...
+			// nudge b a little and try again
+			d := geom.Vector{clipPerturbation, clipPerturbation * 1.37}.Times(float64(try + 1))
+			for i := range b {
+				b[i] = b[i].Plus(d)
+			}
+			continue
+		}
+
+		if !found {
+			return clipDisjoint(a, b, op)
+		}
+
+		clipMark(subject, b, op == CLIP_UNION || op == CLIP_DIFFERENCE)
+		clipMark(clip, a, op == CLIP_UNION)
+		return clipFinish(clipTrace(subject), op), nil
+	}
+}
+
+type clipNode struct {
+	pt         geom.Vector
+	next, prev *clipNode
+	neighbor   *clipNode
+	intersect  bool
+	entry      bool
+	visited    bool
+	alpha      float64
+}
+
+func clipList(poly []geom.Vector) *clipNode {
+	var first, last *clipNode
+	for _, pt := range poly {
+		n := &clipNode{pt: pt}
+		if first == nil {
+			first = n
+		} else {
+			last.next, n.prev = n, last
+		}
+		last = n
+	}
+	last.next, first.prev = first, last
+	return first
+}
+
+// nextVertex skips the intersections
+func (n *clipNode) nextVertex() *clipNode {
+	n = n.next
+	for n.intersect {
+		n = n.next
+	}
+	return n
+}
+
+// insert puts the intersection between the vertices from and to,
+// sorted by alpha
+func (n *clipNode) insert(from, to *clipNode) {
+	cur := from
+	for cur.next != to && cur.next.alpha < n.alpha {
+		cur = cur.next
+	}
+	n.next, n.prev = cur.next, cur
+	cur.next.prev = n
+	cur.next = n
+}
+
+// clipIntersect builds the linked lists with all the intersections.
+// degenerate is true when an intersection falls on a vertex
+func clipIntersect(a, b []geom.Vector) (subject, clip *clipNode, found, degenerate bool) {
+	subject, clip = clipList(a), clipList(b)
+
+	s := subject
+	for {
+		sNext := s.nextVertex()
+		c := clip
+		for {
+			cNext := c.nextVertex()
+
+			ta, tb, hit, bad := clipSegments(s.pt, sNext.pt, c.pt, cNext.pt)
+			if bad {
+				return nil, nil, false, true
+			}
+			if hit {
+				pt := s.pt.Plus(sNext.pt.Minus(s.pt).Times(ta))
+				i1 := &clipNode{pt: pt, intersect: true, alpha: ta}
+				i2 := &clipNode{pt: pt, intersect: true, alpha: tb}
+				i1.neighbor, i2.neighbor = i2, i1
+				i1.insert(s, sNext)
+				i2.insert(c, cNext)
+				found = true
+			}
+
+			c = cNext
+			if c == clip {
+				break
+			}
+		}
+		s = sNext
+		if s == subject {
+			break
+		}
+	}
+	return
+}
+
+// clipSegments intersects p1p2 with q1q2.
+// bad is true for touching or overlapping segments
+func clipSegments(p1, p2, q1, q2 geom.Vector) (ta, tb float64, hit, bad bool) {
+	const eps = 1e-10
+
+	d1, d2 := p2.Minus(p1), q2.Minus(q1)
+	denom := geom.CrossProduct(d1, d2)
+	diff := q1.Minus(p1)
+
+	if math.Abs(denom) < eps {
+		// parallel... overlapping if collinear and sharing some part
+		if math.Abs(geom.CrossProduct(diff, d1)) > eps {
+			return 0, 0, false, false
+		}
+		l := d1.MagnitudeSquared()
+		t0 := geom.DotProduct(diff, d1) / l
+		t1 := geom.DotProduct(q2.Minus(p1), d1) / l
+		if math.Max(t0, t1) < -eps || math.Min(t0, t1) > 1+eps {
+			return 0, 0, false, false
+		}
+		return 0, 0, false, true
+	}
+
+	ta = geom.CrossProduct(diff, d2) / denom
+	tb = geom.CrossProduct(diff, d1) / denom
+	if ta < -eps || ta > 1+eps || tb < -eps || tb > 1+eps {
+		return 0, 0, false, false
+	}
+	if ta < eps || ta > 1-eps || tb < eps || tb > 1-eps {
+		return 0, 0, false, true
+	}
+	return ta, tb, true, false
+}
+
+// clipMark sets the entry flags of the intersections of one list,
+// flipped to keep the outside instead of the inside of the other polygon
+func clipMark(list *clipNode, other []geom.Vector, flip bool) {
+	entry := !isPointInSimplePolygon(list.pt, other)
+	if flip {
+		entry = !entry
+	}
+	n := list
+	for {
+		if n.intersect {
+			n.entry = entry
+			entry = !entry
+		}
+		n = n.next
+		if n == list {
+			break
+		}
+	}
+}
+
+// clipTrace walks from intersection to intersection,
+// switching between the polygons
+func clipTrace(subject *clipNode) (result [][]geom.Vector) {
+	for {
+		var start *clipNode
+		for n := subject.next; ; n = n.next {
+			if n.intersect && !n.visited {
+				start = n
+				break
+			}
+			if n == subject {
+				break
+			}
+		}
+		if start == nil {
+			return
+		}
+
+		poly := []geom.Vector{start.pt}
+		cur := start
+		for {
+			cur.visited, cur.neighbor.visited = true, true
+			if cur.entry {
+				for cur = cur.next; ; cur = cur.next {
+					poly = append(poly, cur.pt)
+					if cur.intersect {
+						break
+					}
+				}
+			} else {
+				for cur = cur.prev; ; cur = cur.prev {
+					poly = append(poly, cur.pt)
+					if cur.intersect {
+						break
+					}
+				}
+			}
+			cur = cur.neighbor
+			if cur.visited {
+				break
+			}
+		}
+		result = append(result, poly)
+	}
+}
+
+// clipFinish cleans up the traced polygons
+func clipFinish(polys [][]geom.Vector, op int) (result [][]geom.Vector) {
+	for _, poly := range polys {
+		poly = cleanPolygon(poly)
+		if len(poly) >= 3 && math.Abs(PolygonArea(poly)) > lineTolerance {
+			result = append(result, counterClockwise(poly))
+		}
+	}
+
+	if op == CLIP_UNION {
+		// the holes are inside the outline, drop them
+		filled := [][]geom.Vector{}
+		for i, poly := range result {
+			hole := false
+			for j, other := range result {
+				if i != j && isPointInSimplePolygon(poly[0], other) && math.Abs(PolygonArea(other)) > math.Abs(PolygonArea(poly)) {
+					hole = true
+					break
+				}
+			}
+			if !hole {
+				filled = append(filled, poly)
+			}
+		}
+		result = filled
+	}
+	return
+}
+
+// clipDisjoint handles boundaries that don't cross:
+// one polygon is inside the other or they are apart
+func clipDisjoint(a, b []geom.Vector, op int) ([][]geom.Vector, error) {
+	aInB := isPointInSimplePolygon(a[0], b)
+	bInA := isPointInSimplePolygon(b[0], a)
+	a, b = counterClockwise(a), counterClockwise(b)
+
+	switch op {
+	case CLIP_INTERSECTION:
+		switch {
+		case aInB:
+			return [][]geom.Vector{a}, nil
+		case bInA:
+			return [][]geom.Vector{b}, nil
+		}
+		return nil, nil
+	case CLIP_UNION:
+		switch {
+		case aInB:
+			return [][]geom.Vector{b}, nil
+		case bInA:
+			return [][]geom.Vector{a}, nil
+		}
+		return [][]geom.Vector{a, b}, nil
+	}
+
+	// difference
+	switch {
+	case aInB:
+		return nil, nil
+	case bInA:
+		// a hole... cut a in two through b, so no piece has a hole
+		return clipHole(a, b)
+	}
+	return [][]geom.Vector{a}, nil
+}
+
+// clipHole cuts b out of a when b is inside a.
+// a is split by a vertical line through b and each half is clipped
+func clipHole(a, b []geom.Vector) (result [][]geom.Vector, err error) {
+	min := geom.Vector{math.Inf(1), math.Inf(1)}
+	max := geom.Vector{math.Inf(-1), math.Inf(-1)}
+	for _, v := range a {
+		min.X, min.Y = math.Min(min.X, v.X), math.Min(min.Y, v.Y)
+		max.X, max.Y = math.Max(max.X, v.X), math.Max(max.Y, v.Y)
+	}
+	min, max = min.Minus(geom.Vector{1, 1}), max.Plus(geom.Vector{1, 1})
+
+	x := PolygonCentroid(b).X
+	halves := [][]geom.Vector{
+		{{min.X, min.Y}, {x, min.Y}, {x, max.Y}, {min.X, max.Y}},
+		{{x, min.Y}, {max.X, min.Y}, {max.X, max.Y}, {x, max.Y}},
+	}
+	for _, half := range halves {
+		parts, err := Intersection(a, half)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			pieces, err := Difference(part, b)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, pieces...)
+		}
+	}
+	return
+}
+
+// clipDegenerate handles polygons without area
+func clipDegenerate(a, b []geom.Vector, op int) [][]geom.Vector {
+	valid := func(p []geom.Vector) [][]geom.Vector {
+		if len(p) < 3 {
+			return nil
+		}
+		return [][]geom.Vector{counterClockwise(p)}
+	}
+	switch op {
+	case CLIP_UNION:
+		return append(valid(a), valid(b)...)
+	case CLIP_DIFFERENCE:
+		return valid(a)
+	}
+	return nil
+}
+
+// isPointInSimplePolygon counts the edges crossed by a ray to the right
+func isPointInSimplePolygon(pt geom.Vector, hull []geom.Vector) bool {
+	inside := false
+	prev := hull[len(hull)-1]
+	for _, next := range hull {
+		if (next.Y > pt.Y) != (prev.Y > pt.Y) {
+			x := next.X + (pt.Y-next.Y)*(prev.X-next.X)/(prev.Y-next.Y)
+			if pt.X < x {
+				inside = !inside
+			}
+		}
+		prev = next
+	}
+	return inside
+}
@@ -0,0 +1,77 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func totalArea(polys [][]geom.Vector) (area float64) {
+	for _, p := range polys {
+		area += math.Abs(PolygonArea(p))
+	}
+	return
+}
+
+// clipped drops the error for the cases that must clip
+func clipped(polys [][]geom.Vector, err error) [][]geom.Vector {
+	So(err, ShouldBeNil)
+	return polys
+}
+
+func Test_Clip(t *testing.T) {
+	Convey("Clip", t, func() {
+		a := []geom.Vector{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+		b := []geom.Vector{{2, 2}, {6, 2}, {6, 6}, {2, 6}}
+
+		Convey("should clip overlapping squares", func() {
+			So(totalArea(clipped(Intersection(a, b))), ShouldAlmostEqual, 4)
+			So(totalArea(clipped(Union(a, b))), ShouldAlmostEqual, 28)
+			So(totalArea(clipped(Difference(a, b))), ShouldAlmostEqual, 12)
+			So(clipped(Difference(a, b)), ShouldHaveLength, 1)
+		})
+
+		Convey("should handle shared edges", func() {
+			c := []geom.Vector{{4, 0}, {8, 0}, {8, 4}, {4, 4}}
+			So(totalArea(clipped(Union(a, c))), ShouldAlmostEqual, 32, 1e-4)
+			So(totalArea(clipped(Intersection(a, c))), ShouldAlmostEqual, 0, 1e-4)
+		})
+
+		Convey("should cut holes into pieces", func() {
+			hole := []geom.Vector{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+			pieces := clipped(Difference(a, hole))
+			So(len(pieces), ShouldBeGreaterThan, 1)
+			So(totalArea(pieces), ShouldAlmostEqual, 12)
+			for _, p := range pieces {
+				_, err := DecomposePolygon(p)
+				So(err, ShouldBeNil)
+			}
+		})
+
+		Convey("should handle apart polygons", func() {
+			far := []geom.Vector{{10, 10}, {11, 10}, {11, 11}}
+			So(clipped(Intersection(a, far)), ShouldBeEmpty)
+			So(clipped(Union(a, far)), ShouldHaveLength, 2)
+			So(totalArea(clipped(Difference(a, far))), ShouldAlmostEqual, 16)
+			So(clipped(Difference(far, a)), ShouldHaveLength, 1)
+		})
+
+		Convey("should work with concave polygons", func() {
+			u := []geom.Vector{{0, 0}, {6, 0}, {6, 4}, {4, 4}, {4, 2}, {2, 2}, {2, 4}, {0, 4}}
+			bar := []geom.Vector{{-1, 3}, {7, 3}, {7, 5}, {-1, 5}}
+			So(totalArea(clipped(Intersection(u, bar))), ShouldAlmostEqual, 4)
+			So(clipped(Intersection(u, bar)), ShouldHaveLength, 2)
+			So(totalArea(clipped(Difference(u, bar))), ShouldAlmostEqual, 16)
+		})
+
+		Convey("should fail when nudging doesn't help", func() {
+			defer func(v float64) { clipPerturbation = v }(clipPerturbation)
+			clipPerturbation = 0
+
+			c := []geom.Vector{{4, 0}, {8, 0}, {8, 4}, {4, 4}}
+			_, err := Union(a, c)
+			So(err, ShouldEqual, ERROR_CLIP_DEGENERATE)
+		})
+	})
+}
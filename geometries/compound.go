@@ -0,0 +1,312 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+	"sort"
+)
+
+// Child is a geometry placed inside a compound geometry
+type Child struct {
+	Geometry Geometry
+	Pos      geom.Vector // the offset from the compound's center of mass
+	Angle    float64
+}
+
+// Container is implemented by geometries made of several parts
+// that collide one by one
+type Container interface {
+	Geometry
+	Parts() []Child
+}
+
+// Field is implemented by geometries made of so many parts that
+// only the ones near the other shape are worth checking, like heightfields
+type Field interface {
+	Geometry
+	// Overlapping returns the indices of the parts that may touch the box
+	// (local coordinates)
+	Overlapping(box geom.AABB) []int
+	Part(i int) Child
+}
+
+// ChildTransform returns the position and the angle of the child
+// when its parent is at pos rotated by angle
+func ChildTransform(child Child, pos geom.Vector, angle float64) (geom.Vector, float64) {
+	trans := geom.NewTransformAngle(angle)
+	return pos.Plus(trans.Rotate(child.Pos)), angle + child.Angle
+}
+
+// Compound is a geometry made of several children.
+// it does not need to be convex, each child does.
+type Compound struct {
+	Children []Child
+}
+
+// NewCompound moves the children so the center of mass
+// (all of them having the same density) is at the origin.
+func NewCompound(children []Child) *Compound {
+	c := &Compound{Children: append([]Child{}, children...)}
+
+	center := childrenCentroid(c.Children)
+	for i := range c.Children {
+		c.Children[i].Pos = c.Children[i].Pos.Minus(center)
+	}
+
+	return c
+}
+
+// Parts returns the children
+func (this *Compound) Parts() []Child { return this.Children }
+
+func (this *Compound) AABB(angle float64) geom.AABB {
+	if len(this.Children) == 0 {
+		return geom.AABB{}
+	}
+
+	min := geom.Vector{math.Inf(1), math.Inf(1)}
+	max := geom.Vector{math.Inf(-1), math.Inf(-1)}
+
+	for _, child := range this.Children {
+		pos, a := ChildTransform(child, geom.Vector{}, angle)
+		aabb := child.Geometry.AABB(a)
+
+		min.X = math.Min(min.X, pos.X+aabb.X-aabb.HW)
+		min.Y = math.Min(min.Y, pos.Y+aabb.Y-aabb.HH)
+		max.X = math.Max(max.X, pos.X+aabb.X+aabb.HW)
+		max.Y = math.Max(max.Y, pos.Y+aabb.Y+aabb.HH)
+	}
+
+	return geom.NewAABB_byMM(min.X, min.Y, max.X, max.Y)
+}
+
+func (this *Compound) farthest(dir geom.Vector, fn func(Geometry, geom.Vector) geom.Vector) (ret geom.Vector) {
+	best := math.Inf(-1)
+	for _, child := range this.Children {
+		trans := geom.NewTransform(child.Pos, child.Angle, geom.Vector{})
+		pt := trans.Translate(trans.Rotate(fn(child.Geometry, trans.RotateInv(dir))))
+		if dot := geom.DotProduct(pt, dir); dot > best {
+			best, ret = dot, pt
+		}
+	}
+	return
+}
+
+// FarthestHullPoint of the convex hull around all the children
+func (this *Compound) FarthestHullPoint(dir geom.Vector) geom.Vector {
+	return this.farthest(dir, func(g Geometry, dir geom.Vector) geom.Vector {
+		return g.FarthestHullPoint(dir)
+	})
+}
+
+func (this *Compound) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
+	return this.farthest(dir, func(g Geometry, dir geom.Vector) geom.Vector {
+		return g.FarthestCorePoint(dir, margin)
+	})
+}
+
+// RayCast returns the closest hit of all the children
+func (this *Compound) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	for i, child := range this.Children {
+		trans := geom.NewTransform(child.Pos, child.Angle, geom.Vector{})
+		localFrom := trans.RotateInv(from.Minus(child.Pos))
+		localTo := trans.RotateInv(to.Minus(child.Pos))
+
+		h, hitChild := child.Geometry.RayCast(localFrom, localTo)
+		if !hitChild || (ok && h.Fraction >= hit.Fraction) {
+			continue
+		}
+
+		ok = true
+		hit = RayHit{
+			Fraction: h.Fraction,
+			Point:    trans.Translate(trans.Rotate(h.Point)),
+			Norm:     trans.Rotate(h.Norm),
+			Child:    i,
+		}
+	}
+	return
+}
+
+func (this *Compound) Area() (area float64) {
+	for _, child := range this.Children {
+		area += child.Geometry.Area()
+	}
+	return
+}
+
+func (this *Compound) Centroid() geom.Vector { return childrenCentroid(this.Children) }
+
+func (this *Compound) ContainsPoint(local geom.Vector) bool {
+	for _, child := range this.Children {
+		trans := geom.NewTransformAngle(child.Angle)
+		if child.Geometry.ContainsPoint(trans.RotateInv(local.Minus(child.Pos))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Outline returns the boundary of the children put together,
+// concave where they make it so.
+// children that don't touch get the convex hull around them
+func (this *Compound) Outline(segments int) []geom.Vector {
+	points := []geom.Vector{}
+	polys := [][]geom.Vector{}
+	for _, child := range this.Children {
+		trans := geom.NewTransform(child.Pos, child.Angle, geom.Vector{})
+		poly := []geom.Vector{}
+		for _, pt := range child.Geometry.Outline(segments) {
+			poly = append(poly, trans.Translate(trans.Rotate(pt)))
+		}
+		points = append(points, poly...)
+		if poly = cleanPolygon(poly); len(poly) >= 3 {
+			polys = append(polys, counterClockwise(poly))
+		}
+	}
+	if outline := unionOutline(polys); outline != nil {
+		return outline
+	}
+	return ConvexHull(points)
+}
+
+type outlineEdge struct{ a, b geom.Vector }
+
+// unionOutline traces the boundary of counter-clockwise polygons.
+// the edges are split where they meet the others and the pieces with
+// nothing on their outer side are chained into loops.
+// it returns nil unless they make one piece, holes are filled
+func unionOutline(polys [][]geom.Vector) []geom.Vector {
+	size := 0.0
+	for _, poly := range polys {
+		for _, pt := range poly {
+			size = math.Max(size, math.Max(math.Abs(pt.X), math.Abs(pt.Y)))
+		}
+	}
+	// how far from an edge its sides are checked
+	eps := 1e-7 * size
+	if eps == 0 {
+		return nil
+	}
+
+	kept := []outlineEdge{}
+	for i, poly := range polys {
+		prev := poly[len(poly)-1]
+		for _, next := range poly {
+			for _, piece := range splitEdge(prev, next, polys, i) {
+				d := piece.b.Minus(piece.a)
+				n := geom.Vector{d.Y, -d.X}.Times(eps / d.Magnitude())
+				mid := piece.a.Plus(d.Times(0.5))
+				outside, inside := mid.Plus(n), mid.Minus(n)
+
+				covered := false
+				for k, other := range polys {
+					// where an earlier polygon has the same edge, that one is kept
+					if isPointInSimplePolygon(outside, other) || k < i && isPointInSimplePolygon(inside, other) {
+						covered = true
+						break
+					}
+				}
+				if !covered {
+					kept = append(kept, piece)
+				}
+			}
+			prev = next
+		}
+	}
+
+	near := func(a, b geom.Vector) bool { return a.Minus(b).MagnitudeSquared() < eps*eps }
+	var outline []geom.Vector
+	used := make([]bool, len(kept))
+	for start := range kept {
+		if used[start] {
+			continue
+		}
+		used[start] = true
+		loop := []geom.Vector{kept[start].a}
+		for end := kept[start].b; !near(end, kept[start].a); {
+			next := -1
+			for k, e := range kept {
+				if !used[k] && near(e.a, end) {
+					next = k
+					break
+				}
+			}
+			if next < 0 {
+				return nil
+			}
+			used[next] = true
+			loop = append(loop, kept[next].a)
+			end = kept[next].b
+		}
+
+		// holes go clockwise
+		if PolygonArea(loop) < 0 {
+			if outline != nil {
+				return nil
+			}
+			outline = loop
+		}
+	}
+	if outline = cleanPolygon(outline); len(outline) < 3 {
+		return nil
+	}
+	return outline
+}
+
+// splitEdge cuts the edge from a to b where the edges
+// of the other polygons cross it or end on it
+func splitEdge(a, b geom.Vector, polys [][]geom.Vector, skip int) (pieces []outlineEdge) {
+	const eps = 1e-10
+
+	d := b.Minus(a)
+	l := d.MagnitudeSquared()
+	if l == 0 {
+		return nil
+	}
+	cuts := []float64{0, 1}
+	for j, poly := range polys {
+		if j == skip {
+			continue
+		}
+		prev := poly[len(poly)-1]
+		for _, next := range poly {
+			e, diff := next.Minus(prev), prev.Minus(a)
+			denom := geom.CrossProduct(d, e)
+			switch {
+			case math.Abs(denom) > eps*math.Sqrt(l*e.MagnitudeSquared()):
+				ta := geom.CrossProduct(diff, e) / denom
+				tb := geom.CrossProduct(diff, d) / denom
+				if tb >= -eps && tb <= 1+eps {
+					cuts = append(cuts, ta)
+				}
+			case math.Abs(geom.CrossProduct(diff, d)) <= eps*l:
+				// collinear, cut where the other edge starts and ends
+				cuts = append(cuts, geom.DotProduct(diff, d)/l, geom.DotProduct(next.Minus(a), d)/l)
+			}
+			prev = next
+		}
+	}
+	sort.Float64s(cuts)
+
+	last := 0.0
+	for _, t := range cuts {
+		if t <= last+eps || t > 1 {
+			continue
+		}
+		pieces = append(pieces, outlineEdge{a.Plus(d.Times(last)), a.Plus(d.Times(t))})
+		last = t
+	}
+	return
+}
+
+// Scale resizes the children that can be resized
+// and moves all of them apart
+func (this *Compound) Scale(factor float64) {
+	for i, child := range this.Children {
+		if s, is := child.Geometry.(Scaler); is {
+			s.Scale(factor)
+		}
+		this.Children[i].Pos = child.Pos.Times(factor)
+	}
+}
@@ -0,0 +1,91 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_Compound(t *testing.T) {
+	Convey("test Compound", t, func() {
+		// an L made of two 2x2 squares
+		c := NewCompound([]Child{
+			{Geometry: NewRectangle(2, 2), Pos: geom.Vector{0, 0}},
+			{Geometry: NewRectangle(2, 2), Pos: geom.Vector{2, 0}},
+		})
+
+		Convey("should move the children around the center of mass", func() {
+			So(c.Children[0].Pos, ShouldResemble, geom.Vector{-1, 0})
+			So(c.Children[1].Pos, ShouldResemble, geom.Vector{1, 0})
+		})
+
+		Convey("aabb", func() {
+			So(c.AABB(0), ShouldResemble, geom.AABB{HW: 2, HH: 1})
+
+			aabb := c.AABB(math.Pi / 2)
+			So(aabb.HW, ShouldAlmostEqual, 1)
+			So(aabb.HH, ShouldAlmostEqual, 2)
+		})
+
+		Convey("FarthestHullPoint", func() {
+			So(c.FarthestHullPoint(geom.Vector{1, 1}), ShouldResemble, geom.Vector{2, 1})
+			So(c.FarthestHullPoint(geom.Vector{-1, -1}), ShouldResemble, geom.Vector{-2, -1})
+		})
+
+		Convey("mass properties", func() {
+			So(c.Area(), ShouldEqual, 8)
+			// a 4x2 box
+			So(GeometryMOI(c), ShouldAlmostEqual, (16.0+4.0)/12.0)
+		})
+
+		Convey("Outline should follow the children", func() {
+			So(c.Outline(8), ShouldHaveLength, 4)
+			So(PolygonArea(c.Outline(8)), ShouldAlmostEqual, -8)
+
+			// a real L, the corner is left out
+			l := NewCompound([]Child{
+				{Geometry: NewRectangle(2, 2), Pos: geom.Vector{0, 0}},
+				{Geometry: NewRectangle(2, 2), Pos: geom.Vector{2, 0}},
+				{Geometry: NewRectangle(2, 2), Pos: geom.Vector{0, 2}},
+			})
+			outline := l.Outline(8)
+			So(outline, ShouldHaveLength, 6)
+			So(PolygonArea(outline), ShouldAlmostEqual, -12)
+			corner := l.Children[1].Pos.Plus(geom.Vector{0, 2})
+			So(isPointInSimplePolygon(corner, outline), ShouldBeFalse)
+			So(isPointInSimplePolygon(l.Children[2].Pos, outline), ShouldBeTrue)
+
+			// overlapping curves
+			ball := NewCompound([]Child{
+				{Geometry: NewRectangle(2, 2), Pos: geom.Vector{0, 0}},
+				{Geometry: NewCircle(1), Pos: geom.Vector{1, 0}},
+			})
+			area := math.Abs(PolygonArea(ball.Outline(32)))
+			So(area, ShouldBeGreaterThan, 4+math.Pi/2-0.1)
+			So(area, ShouldBeLessThan, 4+math.Pi/2)
+
+			apart := NewCompound([]Child{
+				{Geometry: NewRectangle(2, 2), Pos: geom.Vector{0, 0}},
+				{Geometry: NewRectangle(2, 2), Pos: geom.Vector{4, 0}},
+			})
+			So(PolygonArea(apart.Outline(8)), ShouldAlmostEqual, -12)
+		})
+
+		Convey("RayCast should report the child that was hit", func() {
+			hit, ok := c.RayCast(geom.Vector{10, 0}, geom.Vector{-10, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Child, ShouldEqual, 1)
+			So(hit.Point.X, ShouldAlmostEqual, 2)
+			So(hit.Norm, ShouldResemble, geom.Vector{1, 0})
+
+			hit, ok = c.RayCast(geom.Vector{-1, 10}, geom.Vector{-1, -10})
+			So(ok, ShouldBeTrue)
+			So(hit.Child, ShouldEqual, 0)
+			So(hit.Fraction, ShouldAlmostEqual, 9.0/20.0)
+
+			_, ok = c.RayCast(geom.Vector{10, 5}, geom.Vector{-10, 5})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
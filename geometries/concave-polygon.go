@@ -0,0 +1,242 @@
+package geometries
+
+import (
+	"errors"
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+var (
+	ERROR_SELF_INTERSECTING = errors.New("Error: The vertices specified describe a self-intersecting polygon.")
+	ERROR_DEGENERATE        = errors.New("Error: The vertices specified do not enclose any area.")
+)
+
+// NewConcavePolygon decomposes any simple polygon into convex parts.
+// the vertices can be wound either way
+func NewConcavePolygon(outline []geom.Vector) (*Compound, error) {
+	parts, err := DecomposePolygon(outline)
+	if err != nil {
+		return nil, err
+	}
+
+	children := []Child{}
+	for _, part := range parts {
+		children = append(children, Child{
+			Geometry: NewConvexPolygon(part),
+			Pos:      PolygonCentroid(part),
+		})
+	}
+	return NewCompound(children), nil
+}
+
+// DecomposePolygon splits a simple polygon into convex polygons
+// using ear clipping and a Hertel-Mehlhorn merge of the triangles.
+// the parts are counter-clockwise (CrossProduct of consecutive edges >= 0)
+func DecomposePolygon(outline []geom.Vector) ([][]geom.Vector, error) {
+	verts := cleanPolygon(outline)
+	if len(verts) < 3 {
+		return nil, ERROR_DEGENERATE
+	}
+
+	if IsPolygonSelfIntersecting(verts) {
+		return nil, ERROR_SELF_INTERSECTING
+	}
+
+	// PolygonArea is negative for counter-clockwise polygons
+	if PolygonArea(verts) > 0 {
+		for i, j := 0, len(verts)-1; i < j; i, j = i+1, j-1 {
+			verts[i], verts[j] = verts[j], verts[i]
+		}
+	}
+
+	triangles, err := triangulate(verts)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := [][]geom.Vector{}
+	for _, poly := range mergeConvex(verts, triangles) {
+		part := []geom.Vector{}
+		for _, i := range poly {
+			part = append(part, verts[i])
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// IsPolygonSelfIntersecting checks if any two edges that are not
+// next to each other cross or touch
+func IsPolygonSelfIntersecting(hull []geom.Vector) bool {
+	n := len(hull)
+	for i := 0; i < n; i++ {
+		a1, a2 := hull[i], hull[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				// neighbours share a vertex
+				continue
+			}
+			if SegmentsIntersect(a1, a2, hull[j], hull[(j+1)%n]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SegmentsIntersect checks if the segments a1a2 and b1b2 cross or touch
+func SegmentsIntersect(a1, a2, b1, b2 geom.Vector) bool {
+	d1 := geom.CrossProduct(b2.Minus(b1), a1.Minus(b1))
+	d2 := geom.CrossProduct(b2.Minus(b1), a2.Minus(b1))
+	d3 := geom.CrossProduct(a2.Minus(a1), b1.Minus(a1))
+	d4 := geom.CrossProduct(a2.Minus(a1), b2.Minus(a1))
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	onSegment := func(p, q, r geom.Vector) bool {
+		// r is collinear with pq, is it between them?
+		return r.X >= math.Min(p.X, q.X) && r.X <= math.Max(p.X, q.X) &&
+			r.Y >= math.Min(p.Y, q.Y) && r.Y <= math.Max(p.Y, q.Y)
+	}
+
+	return (d1 == 0 && onSegment(b1, b2, a1)) ||
+		(d2 == 0 && onSegment(b1, b2, a2)) ||
+		(d3 == 0 && onSegment(a1, a2, b1)) ||
+		(d4 == 0 && onSegment(a1, a2, b2))
+}
+
+// cleanPolygon drops repeated and collinear vertices
+func cleanPolygon(outline []geom.Vector) []geom.Vector {
+	verts := append([]geom.Vector{}, outline...)
+	for changed := true; changed && len(verts) >= 3; {
+		changed = false
+		for i := 0; i < len(verts) && len(verts) >= 3; i++ {
+			prev := verts[(i+len(verts)-1)%len(verts)]
+			next := verts[(i+1)%len(verts)]
+			if geom.CrossProduct(verts[i].Minus(prev), next.Minus(verts[i])) == 0 {
+				verts = append(verts[:i], verts[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	return verts
+}
+
+// triangulate clips ears off a counter-clockwise polygon
+func triangulate(verts []geom.Vector) ([][]int, error) {
+	index := make([]int, len(verts))
+	for i := range index {
+		index[i] = i
+	}
+
+	triangles := [][]int{}
+	for len(index) > 3 {
+		clipped := false
+		for i := range index {
+			a := index[(i+len(index)-1)%len(index)]
+			b := index[i]
+			c := index[(i+1)%len(index)]
+
+			if !isEar(verts, index, a, b, c) {
+				continue
+			}
+
+			triangles = append(triangles, []int{a, b, c})
+			index = append(index[:i], index[i+1:]...)
+			clipped = true
+			break
+		}
+
+		if !clipped {
+			// every simple polygon has an ear...
+			// so this one must be broken
+			return nil, ERROR_SELF_INTERSECTING
+		}
+	}
+	return append(triangles, index), nil
+}
+
+func isEar(verts []geom.Vector, index []int, a, b, c int) bool {
+	pa, pb, pc := verts[a], verts[b], verts[c]
+
+	if geom.CrossProduct(pb.Minus(pa), pc.Minus(pb)) <= 0 {
+		// reflex vertex
+		return false
+	}
+
+	for _, i := range index {
+		if i == a || i == b || i == c {
+			continue
+		}
+		if isPointInTriangle(verts[i], pa, pb, pc) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPointInTriangle checks a counter-clockwise triangle, edges included
+func isPointInTriangle(pt, a, b, c geom.Vector) bool {
+	return geom.CrossProduct(b.Minus(a), pt.Minus(a)) >= 0 &&
+		geom.CrossProduct(c.Minus(b), pt.Minus(b)) >= 0 &&
+		geom.CrossProduct(a.Minus(c), pt.Minus(c)) >= 0
+}
+
+// mergeConvex removes diagonals between the triangles
+// while the polygons on both sides stay convex (Hertel-Mehlhorn)
+func mergeConvex(verts []geom.Vector, polys [][]int) [][]int {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(polys) && !merged; i++ {
+			for j := i + 1; j < len(polys) && !merged; j++ {
+				poly, ok := mergePolygons(polys[i], polys[j])
+				if !ok || !isIndexedPolygonConvex(verts, poly) {
+					continue
+				}
+				polys[i] = poly
+				polys = append(polys[:j], polys[j+1:]...)
+				merged = true
+			}
+		}
+	}
+	return polys
+}
+
+// mergePolygons joins two counter-clockwise polygons along a shared edge
+func mergePolygons(p, q []int) ([]int, bool) {
+	for i := range p {
+		a, b := p[i], p[(i+1)%len(p)]
+		for j := range q {
+			if q[j] != b || q[(j+1)%len(q)] != a {
+				continue
+			}
+
+			// p from b around to a, then q from a around to b
+			poly := []int{}
+			for k := 1; k <= len(p); k++ {
+				poly = append(poly, p[(i+k)%len(p)])
+			}
+			for k := 2; k < len(q); k++ {
+				poly = append(poly, q[(j+k)%len(q)])
+			}
+			return poly, true
+		}
+	}
+	return nil, false
+}
+
+func isIndexedPolygonConvex(verts []geom.Vector, poly []int) bool {
+	for i := range poly {
+		a := verts[poly[i]]
+		b := verts[poly[(i+1)%len(poly)]]
+		c := verts[poly[(i+2)%len(poly)]]
+		if geom.CrossProduct(b.Minus(a), c.Minus(b)) < 0 {
+			return false
+		}
+	}
+	return true
+}
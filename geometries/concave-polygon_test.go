@@ -0,0 +1,63 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_ConcavePolygon(t *testing.T) {
+	Convey("test ConcavePolygon", t, func() {
+		// an L made of three unit squares
+		l := []geom.Vector{
+			{0, 0},
+			{2, 0},
+			{2, 1},
+			{1, 1},
+			{1, 2},
+			{0, 2},
+		}
+
+		Convey("should decompose into convex parts", func() {
+			parts, err := DecomposePolygon(l)
+			So(err, ShouldBeNil)
+			So(len(parts), ShouldBeLessThanOrEqualTo, 3)
+
+			area := 0.0
+			for _, part := range parts {
+				So(IsPolygonConvex(part), ShouldBeTrue)
+				area += math.Abs(PolygonArea(part))
+			}
+			So(area, ShouldAlmostEqual, 3)
+		})
+
+		Convey("should accept clockwise outlines", func() {
+			reverse := []geom.Vector{}
+			for i := len(l) - 1; i >= 0; i-- {
+				reverse = append(reverse, l[i])
+			}
+			c, err := NewConcavePolygon(reverse)
+			So(err, ShouldBeNil)
+			So(c.Area(), ShouldAlmostEqual, 3)
+		})
+
+		Convey("should keep the center of mass at the origin", func() {
+			c, err := NewConcavePolygon(l)
+			So(err, ShouldBeNil)
+
+			aabb := c.AABB(0)
+			// the centroid of the L is at (5/6, 5/6)
+			So(aabb.X, ShouldAlmostEqual, 1-5.0/6.0)
+			So(aabb.Y, ShouldAlmostEqual, 1-5.0/6.0)
+		})
+
+		Convey("should return errors for broken outlines", func() {
+			_, err := NewConcavePolygon([]geom.Vector{{0, 0}, {1, 1}, {1, 0}, {0, 1}})
+			So(err, ShouldEqual, ERROR_SELF_INTERSECTING)
+
+			_, err = NewConcavePolygon([]geom.Vector{{0, 0}, {1, 1}, {2, 2}})
+			So(err, ShouldEqual, ERROR_DEGENERATE)
+		})
+	})
+}
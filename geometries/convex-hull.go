@@ -0,0 +1,80 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"sort"
+)
+
+// ConvexHull builds the convex hull of the points
+// with Andrew's monotone chain algorithm.
+//
+// The hull is counter-clockwise (CrossProduct of consecutive edges > 0),
+// without duplicate or collinear points. One or two points are returned
+// as they are (a point or a line).
+func ConvexHull(points []geom.Vector) []geom.Vector {
+	pts := append([]geom.Vector{}, points...)
+	sort.Sort(byXY(pts))
+
+	// drop the duplicates
+	unique := pts[:0]
+	for i, pt := range pts {
+		if i == 0 || !pt.EqualsVector(pts[i-1]) {
+			unique = append(unique, pt)
+		}
+	}
+	pts = unique
+
+	if len(pts) < 3 {
+		return pts
+	}
+
+	hull := []geom.Vector{}
+
+	// lower hull
+	for _, pt := range pts {
+		for len(hull) >= 2 && geom.CrossProduct(hull[len(hull)-1].Minus(hull[len(hull)-2]), pt.Minus(hull[len(hull)-1])) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+
+	// upper hull
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		pt := pts[i]
+		for len(hull) >= lower && geom.CrossProduct(hull[len(hull)-1].Minus(hull[len(hull)-2]), pt.Minus(hull[len(hull)-1])) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+
+	// the last point is the first one again
+	hull = hull[:len(hull)-1]
+
+	if len(hull) < 3 {
+		// all the points are on a line...
+		// keep the ends of it
+		return []geom.Vector{pts[0], pts[len(pts)-1]}
+	}
+
+	return hull
+}
+
+// NewConvexPolygonFromPoints builds a polygon from the convex hull
+// of unordered points. Points that do not enclose any area
+// (fewer than 3, all equal or on a line) give ERROR_DEGENERATE
+func NewConvexPolygonFromPoints(points []geom.Vector) (*ConvexPolygon, error) {
+	hull := ConvexHull(points)
+	if len(hull) < 3 {
+		return nil, ERROR_DEGENERATE
+	}
+	return NewConvexPolygon(hull), nil
+}
+
+type byXY []geom.Vector
+
+func (a byXY) Len() int      { return len(a) }
+func (a byXY) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byXY) Less(i, j int) bool {
+	return a[i].X < a[j].X || a[i].X == a[j].X && a[i].Y < a[j].Y
+}
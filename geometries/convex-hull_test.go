@@ -0,0 +1,47 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_ConvexHull(t *testing.T) {
+	Convey("test ConvexHull", t, func() {
+		Convey("should return a counter-clockwise hull", func() {
+			hull := ConvexHull([]geom.Vector{
+				{1, 1}, {0, 2}, {2, 2}, {0, 0}, {2, 0},
+			})
+			So(hull, ShouldResemble, []geom.Vector{{0, 0}, {2, 0}, {2, 2}, {0, 2}})
+		})
+
+		Convey("should drop duplicate and collinear points", func() {
+			hull := ConvexHull([]geom.Vector{
+				{0, 0}, {1, 0}, {2, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}, {0, 1},
+			})
+			So(hull, ShouldResemble, []geom.Vector{{0, 0}, {2, 0}, {2, 2}, {0, 2}})
+		})
+
+		Convey("should handle points on a line", func() {
+			So(ConvexHull([]geom.Vector{{2, 2}, {0, 0}, {1, 1}}), ShouldResemble, []geom.Vector{{0, 0}, {2, 2}})
+			So(ConvexHull([]geom.Vector{{3, 3}, {3, 3}}), ShouldResemble, []geom.Vector{{3, 3}})
+		})
+
+		Convey("NewConvexPolygonFromPoints", func() {
+			// clockwise and with a point inside
+			poly, err := NewConvexPolygonFromPoints([]geom.Vector{
+				{0, 5}, {5, 5}, {2, 2}, {5, 0}, {0, 0},
+			})
+			So(err, ShouldBeNil)
+			So(poly.Vertices, ShouldHaveLength, 4)
+			So(poly.AABB(0), ShouldResemble, geom.AABB{HW: 2.5, HH: 2.5})
+
+			_, err = NewConvexPolygonFromPoints(nil)
+			So(err, ShouldEqual, ERROR_DEGENERATE)
+			_, err = NewConvexPolygonFromPoints([]geom.Vector{{3, 3}, {3, 3}})
+			So(err, ShouldEqual, ERROR_DEGENERATE)
+			_, err = NewConvexPolygonFromPoints([]geom.Vector{{2, 2}, {0, 0}, {1, 1}})
+			So(err, ShouldEqual, ERROR_DEGENERATE)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package geometries
 import (
 	"errors"
 	"github.com/oniproject/physics.go/geom"
+	"math"
 )
 
 var ERROR_NOT_CONVEX = errors.New("Error: The vertices specified do not mathc that of a _convex_ polygon.")
@@ -62,10 +63,14 @@ func (this *ConvexPolygon) AABB(angle float64) geom.AABB {
 }
 
 func (this *ConvexPolygon) FarthestHullPoint(dir geom.Vector) geom.Vector {
+	return this.Vertices[this.farthestHullIndex(dir)]
+}
+
+func (this *ConvexPolygon) farthestHullIndex(dir geom.Vector) int {
 	verts := this.Vertices
 
 	if len(verts) < 2 {
-		return verts[0]
+		return 0
 	}
 
 	prev := geom.DotProduct(verts[0], dir)
@@ -73,9 +78,9 @@ func (this *ConvexPolygon) FarthestHullPoint(dir geom.Vector) geom.Vector {
 
 	if len(verts) == 2 {
 		if val >= prev {
-			return verts[1]
+			return 1
 		} else {
-			return verts[0]
+			return 0
 		}
 	}
 
@@ -92,7 +97,7 @@ func (this *ConvexPolygon) FarthestHullPoint(dir geom.Vector) geom.Vector {
 			i++
 		}
 
-		return verts[i-2]
+		return i - 2
 	} else {
 		// go down
 		i = len(verts)
@@ -102,11 +107,67 @@ func (this *ConvexPolygon) FarthestHullPoint(dir geom.Vector) geom.Vector {
 			prev = geom.DotProduct(verts[i], dir)
 		}
 
-		return verts[(i+1)%len(verts)]
+		return (i + 1) % len(verts)
 	}
 }
 
+// FarthestCorePoint returns the farthest point of the polygon
+// shrunk by margin. margin must be less than the inradius
 func (this *ConvexPolygon) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
-	panic("not implemented")
-	return geom.Vector{0, 0}
+	verts := this.Vertices
+	idx := this.farthestHullIndex(dir)
+	result := verts[idx]
+
+	if len(verts) < 3 || margin == 0 {
+		return result
+	}
+
+	// inward normals of the edges next to the vertex
+	next := inwardNormal(result, verts[(idx+1)%len(verts)])
+	prev := inwardNormal(verts[(idx-1+len(verts))%len(verts)], result)
+
+	// move along the bisector, so both edges
+	// are "margin" away from the new vertex
+	mag := margin / (1 + geom.DotProduct(next, prev))
+	return result.Plus(next.Plus(prev).Times(mag))
+}
+
+// inwardNormal of the edge ab of a polygon around the origin
+func inwardNormal(a, b geom.Vector) geom.Vector {
+	n := b.Minus(a).Perp(true).Unit()
+	if geom.DotProduct(n, a) > 0 {
+		n = n.Times(-1)
+	}
+	return n
+}
+
+func (this *ConvexPolygon) Area() float64         { return math.Abs(this.area) }
+func (this *ConvexPolygon) Centroid() geom.Vector { return geom.Vector{} }
+
+// ContainsPoint checks that the point is on the inner side
+// of every edge, or on it
+func (this *ConvexPolygon) ContainsPoint(local geom.Vector) bool {
+	return isPointInConvex(this.Vertices, local)
+}
+
+func (this *ConvexPolygon) Outline(int) []geom.Vector {
+	return counterClockwise(this.Vertices)
+}
+
+// SetVertices replaces the vertices of the polygon.
+// they are moved so the centroid is at the origin, like in NewConvexPolygon
+func (this *ConvexPolygon) SetVertices(hull []geom.Vector) error {
+	if !IsPolygonConvex(hull) {
+		return ERROR_NOT_CONVEX
+	}
+	this.setVertices(hull)
+	return nil
+}
+
+func (this *ConvexPolygon) Scale(factor float64) {
+	hull := make([]geom.Vector, len(this.Vertices))
+	for i, v := range this.Vertices {
+		hull[i] = v.Times(factor)
+	}
+	this.setVertices(hull)
 }
@@ -96,5 +96,18 @@ func Test_ConvexPolygon(t *testing.T) {
 			v = shape.FarthestHullPoint(geom.Vector{0, -1})
 			So(v, ShouldResemble, shape.Vertices[2])
 		})
+
+		Convey("should forget the cached aabb when the vertices change", func() {
+			shape := NewConvexPolygon([]geom.Vector{{1, 1}, {-1, 1}, {-1, -1}, {1, -1}})
+			So(shape.AABB(0).HW, ShouldEqual, 1)
+
+			shape.Scale(2)
+			So(shape.AABB(0).HW, ShouldEqual, 2)
+
+			So(shape.SetVertices([]geom.Vector{{3, 0}, {0, 3}, {-3, 0}, {0, -3}}), ShouldBeNil)
+			So(shape.AABB(0).HW, ShouldEqual, 3)
+
+			So(shape.SetVertices([]geom.Vector{{0, 0}, {2, 0}, {1, 1}, {2, 2}, {0, 2}}), ShouldEqual, ERROR_NOT_CONVEX)
+		})
 	})
 }
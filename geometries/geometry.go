@@ -5,12 +5,175 @@ import (
 	"math"
 )
 
+// the tolerance for points on lines and segments
+const lineTolerance = 1e-9
+
 type Geometry interface {
 	//Init(Options)
 	//Options() Options
 	AABB(angle float64) geom.AABB
 	FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector
 	FarthestHullPoint(dir geom.Vector) geom.Vector
+
+	// area and centroid in local coordinates.
+	// lines have no area and a centroid by their length
+	Area() float64
+	Centroid() geom.Vector
+	ContainsPoint(local geom.Vector) bool
+	// Outline returns the vertices counter-clockwise.
+	// curves are split into about that many segments.
+	// parts that don't touch have no single outline,
+	// their convex hull is returned
+	Outline(segments int) []geom.Vector
+
+	RayCaster
+}
+
+// Scaler is implemented by geometries that can be resized in place.
+// the factor is relative to the current size
+type Scaler interface {
+	Scale(factor float64)
+}
+
+// MassData holds the mass properties of a geometry
+type MassData struct {
+	Area     float64
+	Mass     float64
+	Centroid geom.Vector // center of mass in local coordinates
+	MOI      float64     // moment of inertia about the centroid
+}
+
+// ComputeMass derives the mass properties of a geometry from its density
+func ComputeMass(g Geometry, density float64) (md MassData) {
+	md.Area = g.Area()
+	md.Mass = density * md.Area
+	md.Centroid = g.Centroid()
+	md.MOI = md.Mass * CentroidalMOI(g)
+	return
+}
+
+// GeometryMOI returns the moment of inertia of the built-in geometries
+// about their origin, for a unit mass
+func GeometryMOI(g Geometry) float64 {
+	switch g := g.(type) {
+	case *Circle:
+		return g.Radius * g.Radius / 2.0
+	case *Rectangle:
+		return (g.Width*g.Width + g.Height*g.Height) / 12.0
+	case *Capsule:
+		return capsuleMOI(g.HalfLength, g.Radius)
+	case *ConvexPolygon:
+		return PolygonMOI(g.Vertices)
+	case *RoundedPolygon:
+		_, moi := roundedPolygonMass(g.Vertices, g.Radius)
+		return moi
+	case *Segment:
+		return g.A.DistanceFromSquared(g.B)/12.0 + g.Centroid().MagnitudeSquared()
+	case *Chain:
+		return childrenMOI(g.segments)
+	case *Heightfield:
+		return childrenMOI(g.Parts())
+	case *Compound:
+		return childrenMOI(g.Children)
+	}
+	return 0
+}
+
+// CentroidalMOI returns the moment of inertia about the centroid
+// for a unit mass (parallel axis theorem)
+func CentroidalMOI(g Geometry) float64 {
+	return GeometryMOI(g) - g.Centroid().MagnitudeSquared()
+}
+
+// childShares returns how much of the mass each child gets.
+// by area, or by length for lines. with neither they share equally
+func childShares(children []Child) []float64 {
+	shares := make([]float64, len(children))
+	total := 0.0
+	for i, child := range children {
+		shares[i] = child.Geometry.Area()
+		total += shares[i]
+	}
+	if total == 0 {
+		for i, child := range children {
+			if seg, ok := child.Geometry.(*Segment); ok {
+				shares[i] = seg.A.DistanceFrom(seg.B)
+				total += shares[i]
+			}
+		}
+	}
+	for i := range shares {
+		if total == 0 {
+			shares[i] = 1.0 / float64(len(children))
+		} else {
+			shares[i] /= total
+		}
+	}
+	return shares
+}
+
+// childCentroid returns the centroid of the child in the parent's coordinates
+func childCentroid(child Child) geom.Vector {
+	return child.Pos.Plus(geom.NewTransformAngle(child.Angle).Rotate(child.Geometry.Centroid()))
+}
+
+func childrenCentroid(children []Child) (ret geom.Vector) {
+	for i, share := range childShares(children) {
+		ret = ret.Plus(childCentroid(children[i]).Times(share))
+	}
+	return
+}
+
+func childrenMOI(children []Child) (moi float64) {
+	// parallel axis theorem for every child
+	for i, share := range childShares(children) {
+		child := children[i]
+		moi += share * (CentroidalMOI(child.Geometry) + childCentroid(child).MagnitudeSquared())
+	}
+	return
+}
+
+// arc returns n+1 points on the circle from the start to the end angle
+func arc(center geom.Vector, radius, start, end float64, n int) []geom.Vector {
+	points := make([]geom.Vector, n+1)
+	for i := range points {
+		a := start + (end-start)*float64(i)/float64(n)
+		points[i] = center.Plus(geom.Vector{math.Cos(a), math.Sin(a)}.Times(radius))
+	}
+	return points
+}
+
+// counterClockwise returns a copy of the vertices
+// with CrossProduct of consecutive edges >= 0
+func counterClockwise(hull []geom.Vector) []geom.Vector {
+	verts := append([]geom.Vector{}, hull...)
+	// PolygonArea is negative for counter-clockwise polygons
+	if PolygonArea(verts) > 0 {
+		for i, j := 0, len(verts)-1; i < j; i, j = i+1, j-1 {
+			verts[i], verts[j] = verts[j], verts[i]
+		}
+	}
+	return verts
+}
+
+// isPointInConvex checks if the point is on the same side of every edge
+// of a convex polygon wound either way, edges included
+func isPointInConvex(hull []geom.Vector, pt geom.Vector) bool {
+	if len(hull) < 3 {
+		return false
+	}
+	pos, neg := false, false
+	prev := hull[len(hull)-1]
+	for _, next := range hull {
+		switch c := geom.CrossProduct(next.Minus(prev), pt.Minus(prev)); {
+		case c > 0:
+			pos = true
+		case c < 0:
+			neg = true
+		}
+		prev = next
+	}
+	return !(pos && neg)
 }
 
 func IsPolygonConvex(hull []geom.Vector) bool {
@@ -65,9 +228,9 @@ func PolygonMOI(hull []geom.Vector) float64 {
 	//panic("not implemented")
 
 	num, denom := 0.0, 0.0
-	prev, next := hull[0], hull[1]
-	for i := 1; i < len(hull); i++ {
-		next = hull[i]
+	// start from the closing edge
+	prev := hull[len(hull)-1]
+	for _, next := range hull {
 		tmp := math.Abs(geom.CrossProduct(next, prev))
 		nsNext := next.MagnitudeSquared()
 		nsPrev := prev.MagnitudeSquared()
@@ -114,6 +114,10 @@ func Test_Geometries(t *testing.T) {
 			So(PolygonMOI(point), ShouldEqual, 0)
 			So(PolygonMOI(line), ShouldEqual, 4.0/12.0)
 			So(PolygonMOI(square), ShouldEqual, 2.0*2.0/6.0)
+
+			box := NewConvexPolygon([]geom.Vector{{0, 0}, {4, 0}, {4, 2}, {0, 2}})
+			So(GeometryMOI(box), ShouldAlmostEqual, GeometryMOI(NewRectangle(4, 2)))
+			So(GeometryMOI(box), ShouldAlmostEqual, 20.0/12.0)
 		})
 
 		Convey("check if points are inside a polygon", func() {
@@ -201,5 +205,34 @@ func Test_Geometries(t *testing.T) {
 			result = NearestPointOnLine(geom.Vector{10, 8}, line1, line2)
 			So(result, ShouldResemble, line2)
 		})
+
+		Convey("every geometry should have an outline it contains", func() {
+			all := []Geometry{
+				NewCircle(1),
+				NewRectangle(2, 1),
+				NewConvexPolygon([]geom.Vector{{0, 0}, {2, 0}, {0, 2}}),
+				NewCapsule(1, 0.5),
+				NewRoundedPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}, 0.5),
+				NewCompound([]Child{{Geometry: NewRectangle(1, 1), Pos: geom.Vector{-0.5, 0}}, {Geometry: NewRectangle(1, 1), Pos: geom.Vector{0.5, 0}}}),
+			}
+			for _, g := range all {
+				outline := g.Outline(32)
+				So(len(outline), ShouldBeGreaterThanOrEqualTo, 3)
+				So(g.ContainsPoint(g.Centroid()), ShouldBeTrue)
+				So(g.ContainsPoint(geom.Vector{10, 10}), ShouldBeFalse)
+
+				// counter-clockwise with about the same area
+				So(PolygonArea(outline), ShouldBeLessThan, 0)
+				So(math.Abs(PolygonArea(outline)), ShouldAlmostEqual, g.Area(), 0.1*g.Area())
+			}
+		})
+
+		Convey("segments should contain the points on them", func() {
+			s := NewSegment(geom.Vector{0, 0}, geom.Vector{2, 2})
+			So(s.ContainsPoint(geom.Vector{1, 1}), ShouldBeTrue)
+			So(s.ContainsPoint(geom.Vector{1, 0}), ShouldBeFalse)
+			So(s.Centroid(), ShouldResemble, geom.Vector{1, 1})
+			So(s.Area(), ShouldEqual, 0)
+		})
 	})
 }
@@ -0,0 +1,189 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// Heightfield is terrain made of height samples Spacing apart.
+// Sample i is at (i * Spacing, Heights[i] * Scale). The solid side is
+// below the surface (greater y), like the ground on the screen.
+//
+// Every column between two samples collides as a one-sided segment
+// with its neighbours as ghost vertices. Call Update after changing Heights.
+type Heightfield struct {
+	Heights  []float64
+	Spacing  float64
+	Scale    float64
+	min, max float64
+}
+
+func NewHeightfield(heights []float64, spacing, scale float64) *Heightfield {
+	h := &Heightfield{
+		Heights: append([]float64{}, heights...),
+		Spacing: spacing,
+		Scale:   scale,
+	}
+	h.Update()
+	return h
+}
+
+// Update finds the height range again
+func (this *Heightfield) Update() {
+	this.min, this.max = math.Inf(1), math.Inf(-1)
+	for i := range this.Heights {
+		y := this.Point(i).Y
+		this.min, this.max = math.Min(this.min, y), math.Max(this.max, y)
+	}
+}
+
+// Point returns the sample i
+func (this *Heightfield) Point(i int) geom.Vector {
+	return geom.Vector{float64(i) * this.Spacing, this.Heights[i] * this.Scale}
+}
+
+// Columns returns the number of columns (segments)
+func (this *Heightfield) Columns() int {
+	if len(this.Heights) < 2 {
+		return 0
+	}
+	return len(this.Heights) - 1
+}
+
+// HeightAt returns the surface under x, ok is false outside of the field
+func (this *Heightfield) HeightAt(x float64) (y float64, ok bool) {
+	i := int(math.Floor(x / this.Spacing))
+	if x < 0 || x > float64(this.Columns())*this.Spacing || this.Columns() == 0 {
+		return 0, false
+	}
+	if i == this.Columns() {
+		i--
+	}
+	a, b := this.Point(i), this.Point(i+1)
+	t := (x - a.X) / this.Spacing
+	return a.Y + (b.Y-a.Y)*t, true
+}
+
+func (this *Heightfield) Part(i int) Child {
+	seg := &Segment{A: this.Point(i), B: this.Point(i + 1), OneSided: true}
+	if i > 0 {
+		prev := this.Point(i - 1)
+		seg.Prev = &prev
+	}
+	if i+2 < len(this.Heights) {
+		next := this.Point(i + 2)
+		seg.Next = &next
+	}
+	return Child{Geometry: seg}
+}
+
+// Overlapping returns the columns under the box
+// whose surface is not below it
+func (this *Heightfield) Overlapping(box geom.AABB) (list []int) {
+	first := int(math.Floor((box.X - box.HW) / this.Spacing))
+	last := int(math.Floor((box.X + box.HW) / this.Spacing))
+	first = int(math.Max(float64(first), 0))
+	last = int(math.Min(float64(last), float64(this.Columns()-1)))
+
+	for i := first; i <= last; i++ {
+		top := math.Min(this.Heights[i], this.Heights[i+1]) * this.Scale
+		if this.Scale < 0 {
+			top = math.Max(this.Heights[i], this.Heights[i+1]) * this.Scale
+		}
+		if top <= box.Y+box.HH {
+			list = append(list, i)
+		}
+	}
+	return
+}
+
+// Parts builds all the columns, Overlapping and Part are cheaper
+func (this *Heightfield) Parts() (parts []Child) {
+	for i := 0; i < this.Columns(); i++ {
+		parts = append(parts, this.Part(i))
+	}
+	return
+}
+
+func (this *Heightfield) AABB(angle float64) geom.AABB {
+	if len(this.Heights) == 0 {
+		return geom.AABB{}
+	}
+
+	width := float64(len(this.Heights)-1) * this.Spacing
+	if angle == 0 {
+		return geom.NewAABB_byMM(0, this.min, width, this.max)
+	}
+
+	trans := geom.NewTransformAngle(angle)
+	min := geom.Vector{math.Inf(1), math.Inf(1)}
+	max := geom.Vector{math.Inf(-1), math.Inf(-1)}
+	for _, v := range []geom.Vector{{0, this.min}, {width, this.min}, {width, this.max}, {0, this.max}} {
+		v = trans.Rotate(v)
+		min.X, min.Y = math.Min(min.X, v.X), math.Min(min.Y, v.Y)
+		max.X, max.Y = math.Max(max.X, v.X), math.Max(max.Y, v.Y)
+	}
+	return geom.NewAABB_byMM(min.X, min.Y, max.X, max.Y)
+}
+
+func (this *Heightfield) FarthestHullPoint(dir geom.Vector) (ret geom.Vector) {
+	best := math.Inf(-1)
+	for i := range this.Heights {
+		v := this.Point(i)
+		if dot := geom.DotProduct(v, dir); dot > best {
+			best, ret = dot, v
+		}
+	}
+	return
+}
+
+func (this *Heightfield) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
+	return this.FarthestHullPoint(dir)
+}
+
+func (this *Heightfield) Area() float64         { return 0 }
+func (this *Heightfield) Centroid() geom.Vector { return childrenCentroid(this.Parts()) }
+
+// ContainsPoint checks if the point is below the surface
+func (this *Heightfield) ContainsPoint(local geom.Vector) bool {
+	y, ok := this.HeightAt(local.X)
+	return ok && local.Y >= y
+}
+
+func (this *Heightfield) Outline(int) []geom.Vector {
+	points := make([]geom.Vector, len(this.Heights))
+	for i := range points {
+		points[i] = this.Point(i)
+	}
+	return points
+}
+
+// RayCast walks the columns along the ray, Child is the column that was hit
+func (this *Heightfield) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	if this.Columns() == 0 {
+		return hit, false
+	}
+
+	// the columns outside of the field don't matter
+	clamp := func(x float64) int {
+		return int(math.Max(-1, math.Min(math.Floor(x/this.Spacing), float64(this.Columns()))))
+	}
+	first, last := clamp(from.X), clamp(to.X)
+	step := 1
+	if last < first {
+		step = -1
+	}
+
+	for i := first; ; i += step {
+		if i >= 0 && i < this.Columns() {
+			seg := this.Part(i).Geometry.(*Segment)
+			if h, hitColumn := seg.RayCast(from, to); hitColumn {
+				h.Child = i
+				return h, true
+			}
+		}
+		if i == last {
+			return hit, false
+		}
+	}
+}
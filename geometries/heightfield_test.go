@@ -0,0 +1,51 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Heightfield(t *testing.T) {
+	Convey("Heightfield", t, func() {
+		h := NewHeightfield([]float64{0, 1, 2, 1, 0}, 2, 0.5)
+
+		Convey("should have a tight AABB", func() {
+			aabb := h.AABB(0)
+			So(aabb, ShouldResemble, geom.NewAABB_byMM(0, 0, 8, 1))
+		})
+
+		Convey("should find the columns under a box", func() {
+			So(h.Overlapping(geom.NewAABB_byMM(1, -5, 3, 5)), ShouldResemble, []int{0, 1})
+			// above the surface
+			So(h.Overlapping(geom.NewAABB_byMM(1, -5, 3, -1)), ShouldBeEmpty)
+			So(h.Overlapping(geom.NewAABB_byMM(20, 0, 30, 5)), ShouldBeEmpty)
+		})
+
+		Convey("should know what is below the surface", func() {
+			y, ok := h.HeightAt(3)
+			So(ok, ShouldBeTrue)
+			So(y, ShouldAlmostEqual, 0.75)
+			So(h.ContainsPoint(geom.Vector{3, 1}), ShouldBeTrue)
+			So(h.ContainsPoint(geom.Vector{3, 0.5}), ShouldBeFalse)
+			So(h.ContainsPoint(geom.Vector{9, 1}), ShouldBeFalse)
+		})
+
+		Convey("should cast rays from above", func() {
+			hit, ok := h.RayCast(geom.Vector{5, -10}, geom.Vector{5, 10})
+			So(ok, ShouldBeTrue)
+			So(hit.Child, ShouldEqual, 2)
+			So(hit.Point.Y, ShouldAlmostEqual, 0.75)
+			So(hit.Norm.Y, ShouldBeLessThan, 0)
+
+			// starts below the surface of the first columns
+			hit, ok = h.RayCast(geom.Vector{-1, 0.9}, geom.Vector{9, 0.9})
+			So(ok, ShouldBeTrue)
+			So(hit.Child, ShouldEqual, 2)
+			So(hit.Point.X, ShouldAlmostEqual, 4.4)
+
+			_, ok = h.RayCast(geom.Vector{5, 10}, geom.Vector{5, -10})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,291 @@
+package geometries
+
+import (
+	"errors"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/util"
+)
+
+var ERROR_UNKNOWN_GEOMETRY = errors.New("Error: Unknown geometry type.")
+
+// GeometryData is the JSON form of a geometry:
+// the registered type name next to the fields of its options
+type GeometryData = util.ComponentData
+
+var registry = util.NewRegistry(ERROR_UNKNOWN_GEOMETRY)
+
+// RegisterGeometry adds a geometry by name. defaults returns a pointer to
+// a new options struct with the default values, the factory gets one like it.
+// describe returns the options of a geometry of this type, so it can be
+// encoded. it can be nil
+func RegisterGeometry(name string, defaults func() interface{}, factory util.Factory, describe util.Describer) error {
+	return registry.Register(name, defaults, factory, describe)
+}
+
+// NewGeometry makes a registered geometry. options can be nil for the defaults
+func NewGeometry(name string, options interface{}) (Geometry, error) {
+	g, err := registry.Create(name, options)
+	if err != nil {
+		return nil, err
+	}
+	return g.(Geometry), nil
+}
+
+// NewGeometryOptions returns the default options of a geometry to fill in
+func NewGeometryOptions(name string) (interface{}, error) { return registry.Options(name) }
+func GeometryNames() []string                             { return registry.Names() }
+
+func EncodeGeometry(g Geometry) (GeometryData, error) {
+	d, err := registry.Encode(g)
+	if err == util.ERROR_UNDESCRIBED {
+		err = ERROR_UNKNOWN_GEOMETRY
+	}
+	return d, err
+}
+
+// DecodeGeometry makes the geometry exactly as it was encoded.
+// Polygons and compounds are not moved to their centroid again
+func DecodeGeometry(d GeometryData) (Geometry, error) {
+	g, err := registry.Decode(d)
+	if err != nil {
+		return nil, err
+	}
+	return g.(Geometry), nil
+}
+
+// CopyGeometry makes a new geometry like g with its registered describer.
+// Geometries that can't be described are returned as they are
+func CopyGeometry(g Geometry) Geometry {
+	name, options, err := registry.Describe(g)
+	if err != nil {
+		return g
+	}
+	c, err := registry.Create(name, options)
+	if err != nil {
+		return g
+	}
+	return c.(Geometry)
+}
+
+// options of the built-in geometries
+
+type CircleOptions struct {
+	Radius float64 `json:"radius"`
+}
+
+type RectangleOptions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type CapsuleOptions struct {
+	HalfLength float64 `json:"halfLength"`
+	Radius     float64 `json:"radius"`
+}
+
+// PolygonOptions are used as they are, the vertices are not moved
+// to the centroid
+type PolygonOptions struct {
+	Vertices []geom.Vector `json:"vertices"`
+	Radius   float64       `json:"radius,omitempty"` // only for rounded polygons
+}
+
+type SegmentOptions struct {
+	A        geom.Vector  `json:"a"`
+	B        geom.Vector  `json:"b"`
+	Prev     *geom.Vector `json:"prev,omitempty"`
+	Next     *geom.Vector `json:"next,omitempty"`
+	OneSided bool         `json:"oneSided"`
+}
+
+type ChainOptions struct {
+	Vertices []geom.Vector `json:"vertices"`
+	Loop     bool          `json:"loop"`
+	OneSided bool          `json:"oneSided"`
+}
+
+type HeightfieldOptions struct {
+	Heights []float64 `json:"heights"`
+	Spacing float64   `json:"spacing"`
+	Scale   float64   `json:"scale"`
+}
+
+// CompoundOptions are used as they are, the children are not moved
+// to the center of mass
+type CompoundOptions struct {
+	Children []ChildData `json:"children"`
+}
+
+type ChildData struct {
+	Geometry GeometryData `json:"geometry"`
+	Pos      geom.Vector  `json:"pos"`
+	Angle    float64      `json:"angle,omitempty"`
+}
+
+func init() {
+	RegisterGeometry("point",
+		func() interface{} { return &struct{}{} },
+		func(interface{}) (interface{}, error) { return NewPoint(), nil },
+		func(g interface{}) (interface{}, bool) {
+			_, ok := g.(*Point)
+			return &struct{}{}, ok
+		})
+	RegisterGeometry("circle",
+		func() interface{} { return &CircleOptions{Radius: 1} },
+		func(options interface{}) (interface{}, error) {
+			return NewCircle(options.(*CircleOptions).Radius), nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			c, ok := g.(*Circle)
+			if !ok {
+				return nil, false
+			}
+			return &CircleOptions{c.Radius}, true
+		})
+	RegisterGeometry("rectangle",
+		func() interface{} { return &RectangleOptions{Width: 1, Height: 1} },
+		func(options interface{}) (interface{}, error) {
+			o := options.(*RectangleOptions)
+			return NewRectangle(o.Width, o.Height), nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			r, ok := g.(*Rectangle)
+			if !ok {
+				return nil, false
+			}
+			return &RectangleOptions{r.Width, r.Height}, true
+		})
+	RegisterGeometry("capsule",
+		func() interface{} { return &CapsuleOptions{HalfLength: 1, Radius: 0.5} },
+		func(options interface{}) (interface{}, error) {
+			o := options.(*CapsuleOptions)
+			return NewCapsule(o.HalfLength, o.Radius), nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			c, ok := g.(*Capsule)
+			if !ok {
+				return nil, false
+			}
+			return &CapsuleOptions{c.HalfLength, c.Radius}, true
+		})
+	RegisterGeometry("convex-polygon",
+		func() interface{} { return &PolygonOptions{} },
+		func(options interface{}) (interface{}, error) {
+			return decodeConvexPolygon(options.(*PolygonOptions).Vertices)
+		},
+		func(g interface{}) (interface{}, bool) {
+			p, ok := g.(*ConvexPolygon)
+			if !ok {
+				return nil, false
+			}
+			return &PolygonOptions{Vertices: append([]geom.Vector{}, p.Vertices...)}, true
+		})
+	RegisterGeometry("rounded-polygon",
+		func() interface{} { return &PolygonOptions{} },
+		func(options interface{}) (interface{}, error) {
+			o := options.(*PolygonOptions)
+			poly, err := decodeConvexPolygon(o.Vertices)
+			if err != nil {
+				return nil, err
+			}
+			return &RoundedPolygon{ConvexPolygon: *poly, Radius: o.Radius}, nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			p, ok := g.(*RoundedPolygon)
+			if !ok {
+				return nil, false
+			}
+			return &PolygonOptions{append([]geom.Vector{}, p.Vertices...), p.Radius}, true
+		})
+	RegisterGeometry("segment",
+		func() interface{} { return &SegmentOptions{} },
+		func(options interface{}) (interface{}, error) {
+			o := options.(*SegmentOptions)
+			return &Segment{
+				A:        o.A,
+				B:        o.B,
+				OneSided: o.OneSided,
+				Prev:     copyVector(o.Prev),
+				Next:     copyVector(o.Next),
+			}, nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			s, ok := g.(*Segment)
+			if !ok {
+				return nil, false
+			}
+			return &SegmentOptions{s.A, s.B, copyVector(s.Prev), copyVector(s.Next), s.OneSided}, true
+		})
+	RegisterGeometry("chain",
+		func() interface{} { return &ChainOptions{OneSided: true} },
+		func(options interface{}) (interface{}, error) {
+			o := options.(*ChainOptions)
+			return NewChain(o.Vertices, o.Loop, o.OneSided), nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			c, ok := g.(*Chain)
+			if !ok {
+				return nil, false
+			}
+			oneSided := len(c.segments) > 0 && c.segments[0].Geometry.(*Segment).OneSided
+			return &ChainOptions{append([]geom.Vector{}, c.Vertices...), c.Loop, oneSided}, true
+		})
+	RegisterGeometry("heightfield",
+		func() interface{} { return &HeightfieldOptions{Spacing: 1, Scale: 1} },
+		func(options interface{}) (interface{}, error) {
+			o := options.(*HeightfieldOptions)
+			return NewHeightfield(o.Heights, o.Spacing, o.Scale), nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			h, ok := g.(*Heightfield)
+			if !ok {
+				return nil, false
+			}
+			return &HeightfieldOptions{append([]float64{}, h.Heights...), h.Spacing, h.Scale}, true
+		})
+	RegisterGeometry("compound",
+		func() interface{} { return &CompoundOptions{} },
+		func(options interface{}) (interface{}, error) {
+			c := &Compound{}
+			for _, cd := range options.(*CompoundOptions).Children {
+				g, err := DecodeGeometry(cd.Geometry)
+				if err != nil {
+					return nil, err
+				}
+				c.Children = append(c.Children, Child{Geometry: g, Pos: cd.Pos, Angle: cd.Angle})
+			}
+			return c, nil
+		},
+		func(g interface{}) (interface{}, bool) {
+			c, ok := g.(*Compound)
+			if !ok {
+				return nil, false
+			}
+			o := &CompoundOptions{}
+			for _, child := range c.Children {
+				d, err := EncodeGeometry(child.Geometry)
+				if err != nil {
+					// a child nobody can describe
+					return nil, false
+				}
+				o.Children = append(o.Children, ChildData{d, child.Pos, child.Angle})
+			}
+			return o, true
+		})
+}
+
+func decodeConvexPolygon(vertices []geom.Vector) (*ConvexPolygon, error) {
+	if !IsPolygonConvex(vertices) {
+		return nil, ERROR_NOT_CONVEX
+	}
+	vertices = append([]geom.Vector{}, vertices...)
+	return &ConvexPolygon{Vertices: vertices, area: PolygonArea(vertices)}, nil
+}
+
+func copyVector(v *geom.Vector) *geom.Vector {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
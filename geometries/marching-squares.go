@@ -0,0 +1,142 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"image"
+)
+
+// TraceImage finds the outlines of the pixels with an alpha
+// of at least threshold, using marching squares. The vertices are in
+// pixels, a pixel covers the square from (x, y) to (x+1, y+1).
+//
+// The solid side is always to the left of the edges (CrossProduct > 0), so
+// outlines are counter-clockwise and holes are clockwise, and the normals
+// of the segments face the empty side. Diagonal pixels are not connected.
+func TraceImage(img image.Image, threshold uint8) [][]geom.Vector {
+	bounds := img.Bounds()
+
+	solid := func(x, y int) bool {
+		if !(image.Point{x, y}).In(bounds) {
+			return false
+		}
+		_, _, _, a := img.At(x, y).RGBA()
+		return uint8(a>>8) >= threshold
+	}
+
+	// the segments by their doubled start point,
+	// every point starts exactly one segment
+	type key struct{ x, y int }
+	next := map[key]key{}
+	order := []key{}
+
+	// a cell has the centers of four pixels as its corners
+	for y := bounds.Min.Y - 1; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X - 1; x < bounds.Max.X; x++ {
+			corners := [4]key{{x, y}, {x + 1, y}, {x + 1, y + 1}, {x, y + 1}}
+			var in [4]bool
+			count := 0
+			for i, c := range corners {
+				in[i] = solid(c.x, c.y)
+				if in[i] {
+					count++
+				}
+			}
+			if count == 0 || count == 4 {
+				continue
+			}
+
+			// the middle of the edge from corner i to the next one
+			mid := func(i int) key {
+				a, b := corners[i], corners[(i+1)%4]
+				return key{a.x + b.x + 1, a.y + b.y + 1}
+			}
+
+			saddle := count == 2 && in[0] == in[2]
+			for i := range corners {
+				// the segment and a solid corner that has to be on its left
+				var from, to, s key
+				switch {
+				case saddle && in[i]:
+					// cut off every solid corner
+					from, to, s = mid(i), mid((i+3)%4), corners[i]
+				case !saddle && !in[i] && in[(i+1)%4]:
+					// entering the solid part along the cycle...
+					// find where it's left
+					j := (i + 1) % 4
+					for in[(j+1)%4] {
+						j = (j + 1) % 4
+					}
+					from, to, s = mid(j), mid(i), corners[(i+1)%4]
+				default:
+					continue
+				}
+
+				d := geom.Vector{float64(to.x - from.x), float64(to.y - from.y)}
+				v := geom.Vector{float64(2*s.x + 1 - from.x), float64(2*s.y + 1 - from.y)}
+				if geom.CrossProduct(d, v) < 0 {
+					from, to = to, from
+				}
+
+				next[from] = to
+				order = append(order, from)
+			}
+		}
+	}
+
+	// link the segments into loops
+	loops := [][]geom.Vector{}
+	for _, start := range order {
+		if _, ok := next[start]; !ok {
+			continue
+		}
+		loop := []geom.Vector{}
+		for k := start; ; {
+			to, ok := next[k]
+			if !ok {
+				break
+			}
+			delete(next, k)
+			loop = append(loop, geom.Vector{float64(k.x) / 2, float64(k.y) / 2})
+			k = to
+		}
+		loops = append(loops, cleanPolygon(loop))
+	}
+	return loops
+}
+
+// FillContours turns traced outlines into simple polygons.
+// the holes (clockwise loops) are cut out of the outlines around them
+func FillContours(loops [][]geom.Vector) (result [][]geom.Vector, err error) {
+	outlines, holes := [][]geom.Vector{}, [][]geom.Vector{}
+	for _, loop := range loops {
+		if len(loop) < 3 {
+			continue
+		}
+		// PolygonArea is negative for counter-clockwise polygons
+		if PolygonArea(loop) < 0 {
+			outlines = append(outlines, loop)
+		} else {
+			holes = append(holes, loop)
+		}
+	}
+
+	for _, outline := range outlines {
+		pieces := [][]geom.Vector{outline}
+		for _, hole := range holes {
+			if !isPointInSimplePolygon(hole[0], outline) {
+				continue
+			}
+			cut := [][]geom.Vector{}
+			for _, piece := range pieces {
+				parts, err := Difference(piece, hole)
+				if err != nil {
+					return nil, err
+				}
+				cut = append(cut, parts...)
+			}
+			pieces = cut
+		}
+		result = append(result, pieces...)
+	}
+	return
+}
@@ -0,0 +1,66 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func Test_MarchingSquares(t *testing.T) {
+	Convey("TraceImage", t, func() {
+		img := image.NewAlpha(image.Rect(0, 0, 8, 8))
+		fill := func(x0, y0, x1, y1 int, a uint8) {
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					img.SetAlpha(x, y, color.Alpha{a})
+				}
+			}
+		}
+
+		Convey("should trace a square", func() {
+			fill(2, 2, 6, 6, 255)
+			loops := TraceImage(img, 128)
+			So(loops, ShouldHaveLength, 1)
+			// the corners are cut
+			So(loops[0], ShouldHaveLength, 8)
+			So(PolygonArea(loops[0]), ShouldBeLessThan, 0)
+			So(math.Abs(PolygonArea(loops[0])), ShouldAlmostEqual, 16-4*0.125)
+		})
+
+		Convey("should respect the threshold", func() {
+			fill(2, 2, 6, 6, 100)
+			So(TraceImage(img, 128), ShouldBeEmpty)
+			So(TraceImage(img, 100), ShouldHaveLength, 1)
+		})
+
+		Convey("should trace holes the other way around", func() {
+			fill(0, 0, 8, 8, 255)
+			fill(3, 3, 5, 5, 0)
+			loops := TraceImage(img, 128)
+			So(loops, ShouldHaveLength, 2)
+			So(PolygonArea(loops[0])*PolygonArea(loops[1]), ShouldBeLessThan, 0)
+
+			pieces, err := FillContours(loops)
+			So(err, ShouldBeNil)
+			So(len(pieces), ShouldBeGreaterThan, 1)
+			area := 0.0
+			for _, p := range pieces {
+				area += math.Abs(PolygonArea(p))
+			}
+			So(area, ShouldAlmostEqual, math.Abs(PolygonArea(loops[0]))-math.Abs(PolygonArea(loops[1])), 1e-4)
+		})
+	})
+
+	Convey("SimplifyPolyline", t, func() {
+		line := []geom.Vector{{0, 0}, {1, 0.05}, {2, -0.05}, {3, 0}, {3, 3}}
+		So(SimplifyPolyline(line, 0.1), ShouldResemble, []geom.Vector{{0, 0}, {3, 0}, {3, 3}})
+		So(SimplifyPolyline(line, 0.01), ShouldHaveLength, 5)
+
+		circle := NewCircle(10).Outline(64)
+		So(len(SimplifyPolygon(circle, 0.5)), ShouldBeLessThan, 20)
+		So(len(SimplifyPolygon(circle, 0.5)), ShouldBeGreaterThan, 4)
+	})
+}
@@ -21,3 +21,19 @@ func (this *Point) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vecto
 	// not implemented.
 	return geom.Vector{0, 0}
 }
+
+func (this *Point) Area() float64             { return 0 }
+func (this *Point) Centroid() geom.Vector     { return geom.Vector{} }
+func (this *Point) Outline(int) []geom.Vector { return []geom.Vector{{}} }
+
+func (this *Point) ContainsPoint(local geom.Vector) bool {
+	return local.Equals(geom.Vector{})
+}
+
+// RayCast never hits a point
+func (this *Point) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	return hit, false
+}
+
+// Scale does nothing, points have no size
+func (this *Point) Scale(factor float64) {}
@@ -0,0 +1,121 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// RayHit describes where a ray hit a geometry
+type RayHit struct {
+	Fraction float64     // the hit point is from + (to - from) * Fraction
+	Point    geom.Vector // the hit point
+	Norm     geom.Vector // the surface normal at the hit point
+	Child    int         // the child that was hit, for compound geometries
+}
+
+// RayCaster is implemented by geometries that can be hit by rays.
+// from and to are in the geometry's local coordinates.
+// rays starting inside the geometry don't hit it
+type RayCaster interface {
+	RayCast(from, to geom.Vector) (hit RayHit, ok bool)
+}
+
+func RayCastCircle(radius float64, from, to geom.Vector) (hit RayHit, ok bool) {
+	d := to.Minus(from)
+
+	// solve |from + d*t| = radius
+	a := d.MagnitudeSquared()
+	b := geom.DotProduct(from, d)
+	c := from.MagnitudeSquared() - radius*radius
+
+	if a == 0 || c < 0 {
+		// no direction or starts inside
+		return hit, false
+	}
+
+	disc := b*b - a*c
+	if disc < 0 {
+		return hit, false
+	}
+
+	t := (-b - math.Sqrt(disc)) / a
+	if t < 0 || t > 1 {
+		return hit, false
+	}
+
+	hit.Fraction = t
+	hit.Point = from.Plus(d.Times(t))
+	hit.Norm = hit.Point.Unit()
+	return hit, true
+}
+
+// RayCastPolygon casts a ray against a convex polygon
+// whose vertices surround the origin
+func RayCastPolygon(hull []geom.Vector, from, to geom.Vector) (hit RayHit, ok bool) {
+	if len(hull) < 3 {
+		return hit, false
+	}
+
+	d := to.Minus(from)
+	enter, exit := math.Inf(-1), 1.0
+	var norm geom.Vector
+
+	prev := hull[len(hull)-1]
+	for _, next := range hull {
+		e := next.Minus(prev)
+		// outward facing normal of the edge
+		n := geom.Vector{e.Y, -e.X}
+		if geom.DotProduct(n, prev) < 0 {
+			n = n.Times(-1)
+		}
+
+		num := geom.DotProduct(n, prev.Minus(from))
+		denom := geom.DotProduct(n, d)
+
+		switch {
+		case denom == 0:
+			// parallel to the edge and outside of it
+			if num < 0 {
+				return hit, false
+			}
+		case denom < 0:
+			// entering
+			if t := num / denom; t > enter {
+				enter, norm = t, n
+			}
+		default:
+			// leaving
+			if t := num / denom; t < exit {
+				exit = t
+			}
+		}
+
+		if enter > exit {
+			return hit, false
+		}
+		prev = next
+	}
+
+	if enter < 0 || enter > 1 {
+		// starts inside or doesn't reach it
+		return hit, false
+	}
+
+	hit.Fraction = enter
+	hit.Point = from.Plus(d.Times(enter))
+	hit.Norm = norm.Unit()
+	return hit, true
+}
+
+func (this *Circle) RayCast(from, to geom.Vector) (RayHit, bool) {
+	return RayCastCircle(this.Radius, from, to)
+}
+
+func (this *ConvexPolygon) RayCast(from, to geom.Vector) (RayHit, bool) {
+	return RayCastPolygon(this.Vertices, from, to)
+}
+
+func (this *Rectangle) RayCast(from, to geom.Vector) (RayHit, bool) {
+	hw, hh := this.Width*0.5, this.Height*0.5
+	return RayCastPolygon([]geom.Vector{{hw, hh}, {hw, -hh}, {-hw, -hh}, {-hw, hh}}, from, to)
+}
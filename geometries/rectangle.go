@@ -2,6 +2,7 @@ package geometries
 
 import (
 	"github.com/oniproject/physics.go/geom"
+	"math"
 )
 
 type Rectangle struct {
@@ -44,7 +45,7 @@ func (this *Rectangle) FarthestHullPoint(dir geom.Vector) geom.Vector {
 	switch {
 	case y < 0:
 		y = -this.Height * 0.5
-	case x > 0:
+	case y > 0:
 		y = this.Height * 0.5
 	default:
 		y = 0
@@ -53,27 +54,45 @@ func (this *Rectangle) FarthestHullPoint(dir geom.Vector) geom.Vector {
 	return geom.Vector{x, y}
 }
 
+// FarthestCorePoint returns the farthest point of the rectangle
+// shrunk by margin on every side
 func (this *Rectangle) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
-	result := this.FarthestHullPoint(dir)
-	x, y := result.X, result.Y
+	x, y := dir.X, dir.Y
 
 	switch {
 	case x < 0:
-		x = -margin * 0.5
+		x = -this.Width*0.5 + margin
 	case x > 0:
-		x = margin * 0.5
+		x = this.Width*0.5 - margin
 	default:
 		x = 0
 	}
 
 	switch {
 	case y < 0:
-		y = -margin * 0.5
-	case x > 0:
-		y = margin * 0.5
+		y = -this.Height*0.5 + margin
+	case y > 0:
+		y = this.Height*0.5 - margin
 	default:
 		y = 0
 	}
 
-	return geom.Vector{0, 0}
+	return geom.Vector{x, y}
+}
+
+func (this *Rectangle) Area() float64         { return this.Width * this.Height }
+func (this *Rectangle) Centroid() geom.Vector { return geom.Vector{} }
+
+func (this *Rectangle) ContainsPoint(local geom.Vector) bool {
+	return math.Abs(local.X) <= this.Width*0.5 && math.Abs(local.Y) <= this.Height*0.5
+}
+
+func (this *Rectangle) Outline(int) []geom.Vector {
+	hw, hh := this.Width*0.5, this.Height*0.5
+	return []geom.Vector{{-hw, -hh}, {hw, -hh}, {hw, hh}, {-hw, hh}}
+}
+
+func (this *Rectangle) Scale(factor float64) {
+	this.Width *= factor
+	this.Height *= factor
 }
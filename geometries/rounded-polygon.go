@@ -0,0 +1,180 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// Rounded is implemented by geometries that are a core shape
+// grown by a margin. FarthestCorePoint(dir, Margin()) is the core.
+type Rounded interface {
+	Margin() float64
+}
+
+// RoundedPolygon is a convex polygon with a skin of Radius around it
+type RoundedPolygon struct {
+	ConvexPolygon
+	Radius float64
+}
+
+func NewRoundedPolygon(hull []geom.Vector, radius float64) *RoundedPolygon {
+	return &RoundedPolygon{ConvexPolygon: *NewConvexPolygon(hull), Radius: radius}
+}
+
+func (this *RoundedPolygon) Margin() float64 { return this.Radius }
+
+func (this *RoundedPolygon) AABB(angle float64) geom.AABB {
+	aabb := this.ConvexPolygon.AABB(angle)
+	aabb.HW += this.Radius
+	aabb.HH += this.Radius
+	return aabb
+}
+
+func (this *RoundedPolygon) FarthestHullPoint(dir geom.Vector) geom.Vector {
+	return this.FarthestCorePoint(dir, 0)
+}
+
+func (this *RoundedPolygon) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
+	if margin > this.Radius {
+		// deeper than the skin
+		return this.ConvexPolygon.FarthestCorePoint(dir, margin-this.Radius)
+	}
+
+	pt := this.ConvexPolygon.FarthestHullPoint(dir)
+	if dir.Equals(geom.Vector{}) {
+		return pt
+	}
+	return pt.Plus(dir.Unit().Times(this.Radius - margin))
+}
+
+// RayCast checks the edges pushed out by the radius
+// and the circles around the vertices
+func (this *RoundedPolygon) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	verts, r := this.Vertices, this.Radius
+
+	if IsPointInPolygon(from, verts) {
+		return hit, false
+	}
+
+	prev := verts[len(verts)-1]
+	for _, next := range verts {
+		if NearestPointOnLine(from, prev, next).DistanceFromSquared(from) < r*r {
+			// starts inside the skin
+			return hit, false
+		}
+
+		n := inwardNormal(prev, next).Times(-r)
+		edge := &Segment{A: prev.Plus(n), B: next.Plus(n)}
+		if h, hitEdge := edge.RayCast(from, to); hitEdge && (!ok || h.Fraction < hit.Fraction) {
+			if geom.DotProduct(h.Norm, n) > 0 {
+				hit, ok = h, true
+			}
+		}
+
+		if h, hitCorner := RayCastCircle(r, from.Minus(next), to.Minus(next)); hitCorner && (!ok || h.Fraction < hit.Fraction) {
+			h.Point = h.Point.Plus(next)
+			hit, ok = h, true
+		}
+
+		prev = next
+	}
+	return
+}
+
+// roundedPolygonMass returns the area and the moment of inertia
+// for a unit mass: the polygon, a box along every edge and
+// a piece of a circle at every vertex
+func roundedPolygonMass(hull []geom.Vector, r float64) (area, moi float64) {
+	polyArea := math.Abs(PolygonArea(hull))
+	area = polyArea
+	moi = polyArea * PolygonMOI(hull)
+
+	if r == 0 || len(hull) < 3 {
+		if area == 0 {
+			return 0, PolygonMOI(hull)
+		}
+		return area, moi / area
+	}
+
+	for i, v := range hull {
+		next := hull[(i+1)%len(hull)]
+		prev := hull[(i-1+len(hull))%len(hull)]
+
+		// the box along the edge to the next vertex
+		n := inwardNormal(v, next).Times(-1)
+		length := next.DistanceFrom(v)
+		center := v.Plus(next).Times(0.5).Plus(n.Times(r / 2))
+		boxArea := length * r
+		area += boxArea
+		moi += boxArea * ((length*length+r*r)/12.0 + center.MagnitudeSquared())
+
+		// the piece of the circle between the edges
+		nPrev := inwardNormal(prev, v).Times(-1)
+		theta := math.Acos(math.Max(-1, math.Min(1, geom.DotProduct(nPrev, n))))
+		if theta == 0 {
+			continue
+		}
+		sectorArea := theta * r * r / 2
+		bisector := nPrev.Plus(n).Unit()
+		offset := bisector.Times(4 * r * math.Sin(theta/2) / (3 * theta))
+		area += sectorArea
+		// about the vertex, then moved to the origin
+		moi += sectorArea * (r*r/2 - offset.MagnitudeSquared() + v.Plus(offset).MagnitudeSquared())
+	}
+
+	return area, moi / area
+}
+
+func (this *RoundedPolygon) Area() float64 {
+	area, _ := roundedPolygonMass(this.Vertices, this.Radius)
+	return area
+}
+
+// ContainsPoint checks the core polygon and the skin around it
+func (this *RoundedPolygon) ContainsPoint(local geom.Vector) bool {
+	if isPointInConvex(this.Vertices, local) {
+		return true
+	}
+	r2 := this.Radius * this.Radius
+	prev := this.Vertices[len(this.Vertices)-1]
+	for _, next := range this.Vertices {
+		if NearestPointOnLine(local, prev, next).DistanceFromSquared(local) <= r2 {
+			return true
+		}
+		prev = next
+	}
+	return false
+}
+
+// Outline rounds every corner with an arc,
+// the segments are shared between the corners by their angle
+func (this *RoundedPolygon) Outline(segments int) []geom.Vector {
+	verts := counterClockwise(this.Vertices)
+	if this.Radius == 0 || len(verts) < 3 {
+		return verts
+	}
+
+	points := []geom.Vector{}
+	for i, v := range verts {
+		prev := verts[(i-1+len(verts))%len(verts)]
+		next := verts[(i+1)%len(verts)]
+
+		// from the normal of the previous edge to the normal of the next one
+		nPrev := inwardNormal(prev, v).Times(-1)
+		n := inwardNormal(v, next).Times(-1)
+		theta := math.Acos(math.Max(-1, math.Min(1, geom.DotProduct(nPrev, n))))
+
+		count := int(float64(segments) * theta / (2 * math.Pi))
+		if count < 1 {
+			count = 1
+		}
+		start := math.Atan2(nPrev.Y, nPrev.X)
+		points = append(points, arc(v, this.Radius, start, start+theta, count)...)
+	}
+	return points
+}
+
+func (this *RoundedPolygon) Scale(factor float64) {
+	this.ConvexPolygon.Scale(factor)
+	this.Radius *= factor
+}
@@ -0,0 +1,68 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_RoundedPolygon(t *testing.T) {
+	square := []geom.Vector{{1, 1}, {1, -1}, {-1, -1}, {-1, 1}}
+
+	Convey("test core points", t, func() {
+		Convey("ConvexPolygon should shrink by the margin", func() {
+			poly := NewConvexPolygon(square)
+			pt := poly.FarthestCorePoint(geom.Vector{1, 0.1}, 0.5)
+			So(pt.X, ShouldAlmostEqual, 0.5)
+			So(pt.Y, ShouldAlmostEqual, 0.5)
+
+			So(poly.FarthestCorePoint(geom.Vector{1, 0.1}, 0), ShouldResemble, geom.Vector{1, 1})
+		})
+
+		Convey("Rectangle should shrink by the margin", func() {
+			r := NewRectangle(4, 2)
+			So(r.FarthestCorePoint(geom.Vector{1, -1}, 0.5), ShouldResemble, geom.Vector{1.5, -0.5})
+			So(r.FarthestCorePoint(geom.Vector{-1, 0}, 0.5), ShouldResemble, geom.Vector{-1.5, 0})
+		})
+	})
+
+	Convey("test RoundedPolygon", t, func() {
+		r := NewRoundedPolygon(square, 1)
+
+		Convey("aabb", func() {
+			So(r.AABB(0), ShouldResemble, geom.AABB{HW: 2, HH: 2})
+		})
+
+		Convey("FarthestHullPoint", func() {
+			So(r.FarthestHullPoint(geom.Vector{1, 0.1}).X, ShouldAlmostEqual, 1+1/math.Sqrt(1.01))
+			pt := r.FarthestHullPoint(geom.Vector{1, 1})
+			So(pt.X, ShouldAlmostEqual, 1+math.Sqrt(0.5))
+		})
+
+		Convey("FarthestCorePoint", func() {
+			So(r.FarthestCorePoint(geom.Vector{1, 0.1}, 1), ShouldResemble, geom.Vector{1, 1})
+			pt := r.FarthestCorePoint(geom.Vector{1, 0.1}, 1.5)
+			So(pt.X, ShouldAlmostEqual, 0.5)
+		})
+
+		Convey("mass properties", func() {
+			So(r.Area(), ShouldAlmostEqual, 4+8+math.Pi)
+			So(GeometryMOI(NewRoundedPolygon(square, 0)), ShouldAlmostEqual, PolygonMOI(square))
+		})
+
+		Convey("RayCast", func() {
+			hit, ok := r.RayCast(geom.Vector{10, 0}, geom.Vector{0, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Point.X, ShouldAlmostEqual, 2)
+			So(hit.Norm, ShouldResemble, geom.Vector{1, 0})
+
+			hit, ok = r.RayCast(geom.Vector{5, 5}, geom.Vector{0, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Point.X, ShouldAlmostEqual, 1+math.Sqrt(0.5))
+
+			_, ok = r.RayCast(geom.Vector{1.5, 0}, geom.Vector{10, 0})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
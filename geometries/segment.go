@@ -0,0 +1,203 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// Segment is a line from A to B.
+//
+// The normal points to the right of A->B, which is outward when segments
+// are wound like a counter-clockwise polygon. A one-sided segment only
+// collides with things in front of it. Prev and Next are the ghost
+// vertices of the neighbours in a chain, nil at the ends.
+type Segment struct {
+	A, B       geom.Vector
+	OneSided   bool
+	Prev, Next *geom.Vector
+}
+
+func NewSegment(a, b geom.Vector) *Segment {
+	return &Segment{A: a, B: b}
+}
+
+func (this *Segment) Scale(factor float64) {
+	this.A = this.A.Times(factor)
+	this.B = this.B.Times(factor)
+}
+
+// Normal returns the unit normal of the front side
+func (this *Segment) Normal() geom.Vector {
+	return this.B.Minus(this.A).Perp(true).Unit()
+}
+
+func (this *Segment) Area() float64             { return 0 }
+func (this *Segment) Centroid() geom.Vector     { return this.A.Plus(this.B).Times(0.5) }
+func (this *Segment) Outline(int) []geom.Vector { return []geom.Vector{this.A, this.B} }
+
+// ContainsPoint checks if the point lies on the segment
+func (this *Segment) ContainsPoint(local geom.Vector) bool {
+	return NearestPointOnLine(local, this.A, this.B).DistanceFromSquared(local) <= lineTolerance*lineTolerance
+}
+
+func (this *Segment) AABB(angle float64) geom.AABB {
+	trans := geom.NewTransformAngle(angle)
+	a, b := trans.Rotate(this.A), trans.Rotate(this.B)
+	return geom.NewAABB_byPoints(a, b)
+}
+
+func (this *Segment) FarthestHullPoint(dir geom.Vector) geom.Vector {
+	if geom.DotProduct(this.B, dir) > geom.DotProduct(this.A, dir) {
+		return this.B
+	}
+	return this.A
+}
+
+// FarthestCorePoint of a segment is the segment itself, it has no margin
+func (this *Segment) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
+	return this.FarthestHullPoint(dir)
+}
+
+func (this *Segment) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	d := to.Minus(from)
+	e := this.B.Minus(this.A)
+	n := this.Normal()
+
+	denom := geom.CrossProduct(d, e)
+	if denom == 0 {
+		// parallel
+		return hit, false
+	}
+
+	if this.OneSided && geom.DotProduct(d, n) >= 0 {
+		// comes from behind
+		return hit, false
+	}
+
+	diff := this.A.Minus(from)
+	t := geom.CrossProduct(diff, e) / denom
+	s := geom.CrossProduct(diff, d) / denom
+	if t < 0 || t > 1 || s < 0 || s > 1 {
+		return hit, false
+	}
+
+	if geom.DotProduct(d, n) > 0 {
+		n = n.Times(-1)
+	}
+
+	hit.Fraction = t
+	hit.Point = from.Plus(d.Times(t))
+	hit.Norm = n
+	return hit, true
+}
+
+// Chain is a polyline of connected segments. Each segment knows its
+// neighbours, so bodies sliding along the chain don't catch on the
+// vertices inside it.
+type Chain struct {
+	Vertices []geom.Vector
+	Loop     bool
+	segments []Child
+}
+
+// NewChain connects the vertices. If loop is set the last vertex
+// is connected to the first one.
+func NewChain(vertices []geom.Vector, loop, oneSided bool) *Chain {
+	c := &Chain{Vertices: append([]geom.Vector{}, vertices...), Loop: loop}
+
+	n := len(c.Vertices)
+	count := n - 1
+	if loop {
+		count = n
+	}
+
+	for i := 0; i < count; i++ {
+		seg := &Segment{
+			A:        c.Vertices[i],
+			B:        c.Vertices[(i+1)%n],
+			OneSided: oneSided,
+		}
+		if i > 0 || loop {
+			seg.Prev = &c.Vertices[(i+n-1)%n]
+		}
+		if i < n-2 || loop {
+			seg.Next = &c.Vertices[(i+2)%n]
+		}
+		c.segments = append(c.segments, Child{Geometry: seg})
+	}
+
+	return c
+}
+
+// Parts returns the segments
+func (this *Chain) Parts() []Child { return this.segments }
+
+func (this *Chain) Area() float64         { return 0 }
+func (this *Chain) Centroid() geom.Vector { return childrenCentroid(this.segments) }
+
+// ContainsPoint checks if the point lies on any of the segments
+func (this *Chain) ContainsPoint(local geom.Vector) bool {
+	for _, child := range this.segments {
+		if child.Geometry.ContainsPoint(local) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scale moves the vertices in place,
+// so the ghost vertices of the segments follow
+func (this *Chain) Scale(factor float64) {
+	for i := range this.Vertices {
+		this.Vertices[i] = this.Vertices[i].Times(factor)
+	}
+	for _, child := range this.segments {
+		child.Geometry.(*Segment).Scale(factor)
+	}
+}
+
+func (this *Chain) Outline(int) []geom.Vector {
+	return append([]geom.Vector{}, this.Vertices...)
+}
+
+func (this *Chain) AABB(angle float64) geom.AABB {
+	if len(this.Vertices) == 0 {
+		return geom.AABB{}
+	}
+
+	trans := geom.NewTransformAngle(angle)
+	min := geom.Vector{math.Inf(1), math.Inf(1)}
+	max := geom.Vector{math.Inf(-1), math.Inf(-1)}
+	for _, v := range this.Vertices {
+		v = trans.Rotate(v)
+		min.X, min.Y = math.Min(min.X, v.X), math.Min(min.Y, v.Y)
+		max.X, max.Y = math.Max(max.X, v.X), math.Max(max.Y, v.Y)
+	}
+	return geom.NewAABB_byMM(min.X, min.Y, max.X, max.Y)
+}
+
+func (this *Chain) FarthestHullPoint(dir geom.Vector) (ret geom.Vector) {
+	best := math.Inf(-1)
+	for _, v := range this.Vertices {
+		if dot := geom.DotProduct(v, dir); dot > best {
+			best, ret = dot, v
+		}
+	}
+	return
+}
+
+func (this *Chain) FarthestCorePoint(dir geom.Vector, margin float64) geom.Vector {
+	return this.FarthestHullPoint(dir)
+}
+
+// RayCast returns the closest hit, Child is the index of the segment
+func (this *Chain) RayCast(from, to geom.Vector) (hit RayHit, ok bool) {
+	for i, child := range this.segments {
+		h, hitSegment := child.Geometry.(*Segment).RayCast(from, to)
+		if hitSegment && (!ok || h.Fraction < hit.Fraction) {
+			hit, ok = h, true
+			hit.Child = i
+		}
+	}
+	return
+}
@@ -0,0 +1,62 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Segment(t *testing.T) {
+	Convey("test Segment", t, func() {
+		s := NewSegment(geom.Vector{0, 0}, geom.Vector{4, 0})
+
+		Convey("should have its normal on the right", func() {
+			So(s.Normal(), ShouldResemble, geom.Vector{0, -1})
+		})
+
+		Convey("FarthestHullPoint", func() {
+			So(s.FarthestHullPoint(geom.Vector{1, 1}), ShouldResemble, geom.Vector{4, 0})
+			So(s.FarthestHullPoint(geom.Vector{-1, 1}), ShouldResemble, geom.Vector{0, 0})
+		})
+
+		Convey("RayCast", func() {
+			hit, ok := s.RayCast(geom.Vector{1, -2}, geom.Vector{1, 2})
+			So(ok, ShouldBeTrue)
+			So(hit.Fraction, ShouldEqual, 0.5)
+			So(hit.Norm, ShouldResemble, geom.Vector{0, -1})
+
+			_, ok = s.RayCast(geom.Vector{1, 2}, geom.Vector{1, -2})
+			So(ok, ShouldBeTrue)
+
+			s.OneSided = true
+			_, ok = s.RayCast(geom.Vector{1, 2}, geom.Vector{1, -2})
+			So(ok, ShouldBeFalse)
+		})
+	})
+
+	Convey("test Chain", t, func() {
+		c := NewChain([]geom.Vector{{0, 0}, {2, 0}, {4, -1}}, false, true)
+
+		Convey("should link the segments with ghost vertices", func() {
+			parts := c.Parts()
+			So(parts, ShouldHaveLength, 2)
+
+			first := parts[0].Geometry.(*Segment)
+			second := parts[1].Geometry.(*Segment)
+			So(first.Prev, ShouldBeNil)
+			So(*first.Next, ShouldResemble, geom.Vector{4, -1})
+			So(*second.Prev, ShouldResemble, geom.Vector{0, 0})
+			So(second.Next, ShouldBeNil)
+		})
+
+		Convey("aabb", func() {
+			So(c.AABB(0), ShouldResemble, geom.AABB{X: 2, Y: -0.5, HW: 2, HH: 0.5})
+		})
+
+		Convey("RayCast should report the segment", func() {
+			hit, ok := c.RayCast(geom.Vector{3, -5}, geom.Vector{3, 5})
+			So(ok, ShouldBeTrue)
+			So(hit.Child, ShouldEqual, 1)
+		})
+	})
+}
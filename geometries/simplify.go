@@ -0,0 +1,70 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+)
+
+// SimplifyPolyline drops the points closer than tolerance to the line
+// through their neighbours (Ramer-Douglas-Peucker). the ends are kept
+func SimplifyPolyline(points []geom.Vector, tolerance float64) []geom.Vector {
+	if len(points) < 3 {
+		return append([]geom.Vector{}, points...)
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+	rdp(points, 0, len(points)-1, tolerance, keep)
+
+	result := []geom.Vector{}
+	for i, pt := range points {
+		if keep[i] {
+			result = append(result, pt)
+		}
+	}
+	return result
+}
+
+// SimplifyPolygon simplifies a closed loop. it's split at the vertex
+// farthest from the first one and both halves are simplified
+func SimplifyPolygon(hull []geom.Vector, tolerance float64) []geom.Vector {
+	if len(hull) < 4 {
+		return append([]geom.Vector{}, hull...)
+	}
+
+	far, best := 0, -1.0
+	for i, pt := range hull {
+		if d := pt.DistanceFromSquared(hull[0]); d > best {
+			far, best = i, d
+		}
+	}
+
+	first := SimplifyPolyline(hull[:far+1], tolerance)
+	second := SimplifyPolyline(append(append([]geom.Vector{}, hull[far:]...), hull[0]), tolerance)
+
+	// both halves have the split points at their ends
+	result := append(first, second[1:len(second)-1]...)
+	return cleanPolygon(result)
+}
+
+func rdp(points []geom.Vector, first, last int, tolerance float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+
+	index, best := -1, tolerance
+	for i := first + 1; i < last; i++ {
+		pt := points[i]
+		if d := NearestPointOnLine(pt, points[first], points[last]).DistanceFrom(pt); d > best {
+			index, best = i, d
+		}
+	}
+
+	if index < 0 {
+		// everything in between is close enough
+		return
+	}
+
+	keep[index] = true
+	rdp(points, first, index, tolerance, keep)
+	rdp(points, index, last, tolerance, keep)
+}
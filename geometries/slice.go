@@ -0,0 +1,64 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// SlicePolygon cuts a convex polygon along the line through a and b.
+// left is on the side where CrossProduct(b - a, pt - a) > 0.
+// ok is false if the line doesn't cut the polygon into two pieces
+func SlicePolygon(hull []geom.Vector, a, b geom.Vector) (left, right []geom.Vector, ok bool) {
+	dir := b.Minus(a)
+	if len(hull) < 3 || dir.Equals(geom.Vector{}) {
+		return nil, nil, false
+	}
+
+	side := func(pt geom.Vector) float64 {
+		return geom.CrossProduct(dir, pt.Minus(a))
+	}
+
+	prev := hull[len(hull)-1]
+	sPrev := side(prev)
+	for _, next := range hull {
+		sNext := side(next)
+
+		if (sPrev > 0 && sNext < 0) || (sPrev < 0 && sNext > 0) {
+			// the edge crosses the line
+			t := sPrev / (sPrev - sNext)
+			pt := prev.Plus(next.Minus(prev).Times(t))
+			left = append(left, pt)
+			right = append(right, pt)
+		}
+
+		if sNext >= 0 {
+			left = append(left, next)
+		}
+		if sNext <= 0 {
+			right = append(right, next)
+		}
+
+		prev, sPrev = next, sNext
+	}
+
+	if len(left) < 3 || len(right) < 3 ||
+		math.Abs(PolygonArea(left)) < lineTolerance || math.Abs(PolygonArea(right)) < lineTolerance {
+		return nil, nil, false
+	}
+	return left, right, true
+}
+
+// Slice cuts the polygon along the line through a and b
+// (in local coordinates). Both pieces are centered on their own centroid,
+// which is returned in the polygon's coordinates
+func (this *ConvexPolygon) Slice(a, b geom.Vector) (pieces []*ConvexPolygon, centers []geom.Vector, ok bool) {
+	left, right, ok := SlicePolygon(this.Vertices, a, b)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, hull := range [][]geom.Vector{left, right} {
+		pieces = append(pieces, NewConvexPolygon(hull))
+		centers = append(centers, PolygonCentroid(hull))
+	}
+	return pieces, centers, true
+}
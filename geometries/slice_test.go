@@ -0,0 +1,37 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_Slice(t *testing.T) {
+	Convey("SlicePolygon", t, func() {
+		square := []geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+
+		Convey("should cut a square in two halves", func() {
+			left, right, ok := SlicePolygon(square, geom.Vector{0, -5}, geom.Vector{0, 5})
+			So(ok, ShouldBeTrue)
+			So(math.Abs(PolygonArea(left)), ShouldAlmostEqual, 2)
+			So(math.Abs(PolygonArea(right)), ShouldAlmostEqual, 2)
+			So(PolygonCentroid(left).X, ShouldAlmostEqual, -0.5)
+			So(PolygonCentroid(right).X, ShouldAlmostEqual, 0.5)
+		})
+
+		Convey("should cut through the corners", func() {
+			left, right, ok := SlicePolygon(square, geom.Vector{-1, -1}, geom.Vector{1, 1})
+			So(ok, ShouldBeTrue)
+			So(len(left), ShouldEqual, 3)
+			So(len(right), ShouldEqual, 3)
+		})
+
+		Convey("should not cut when the line misses", func() {
+			_, _, ok := SlicePolygon(square, geom.Vector{2, -5}, geom.Vector{2, 5})
+			So(ok, ShouldBeFalse)
+			_, _, ok = SlicePolygon(square, geom.Vector{1, -5}, geom.Vector{1, 5})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
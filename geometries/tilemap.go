@@ -0,0 +1,111 @@
+package geometries
+
+import (
+	"github.com/oniproject/physics.go/geom"
+	"math"
+)
+
+// The kinds of tiles. A slope is the half of the tile
+// with the right angle in that corner (rows go down the screen)
+const (
+	TILE_EMPTY = iota
+	TILE_SOLID
+	TILE_SLOPE_TL
+	TILE_SLOPE_TR
+	TILE_SLOPE_BR
+	TILE_SLOPE_BL
+)
+
+// the corners of a tile around the cycle, the dropped one for slopes
+var tileCorners = [4][2]int{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+var tileDropped = map[int]int{
+	TILE_SOLID:    -1,
+	TILE_SLOPE_TL: 2,
+	TILE_SLOPE_TR: 3,
+	TILE_SLOPE_BR: 0,
+	TILE_SLOPE_BL: 1,
+}
+
+// TileOutlines merges the tiles of the grid (grid[row][column]) into
+// outlines without the edges between the tiles. Tile (column, row) covers
+// the square from (column, row) to (column+1, row+1).
+//
+// Like TraceImage, the solid side is to the left of the edges,
+// so outlines are counter-clockwise and holes are clockwise.
+// Tiles touching only by their corners are not connected.
+func TileOutlines(grid [][]int) [][]geom.Vector {
+	type point struct{ x, y int }
+	type edge struct{ from, to point }
+
+	// every edge of every tile, the ones between two tiles cancel out
+	edges := map[edge]bool{}
+	order := []edge{}
+	for row, line := range grid {
+		for col, kind := range line {
+			dropped, ok := tileDropped[kind]
+			if !ok {
+				continue
+			}
+
+			corners := []point{}
+			for i, c := range tileCorners {
+				if i != dropped {
+					corners = append(corners, point{col + c[0], row + c[1]})
+				}
+			}
+
+			for i, from := range corners {
+				e := edge{from, corners[(i+1)%len(corners)]}
+				if back := (edge{e.to, e.from}); edges[back] {
+					delete(edges, back)
+					continue
+				}
+				edges[e] = true
+				order = append(order, e)
+			}
+		}
+	}
+
+	outgoing := map[point][]edge{}
+	for _, e := range order {
+		if edges[e] {
+			outgoing[e.from] = append(outgoing[e.from], e)
+		}
+	}
+
+	vector := func(e edge) geom.Vector {
+		return geom.Vector{float64(e.to.x - e.from.x), float64(e.to.y - e.from.y)}
+	}
+
+	loops := [][]geom.Vector{}
+	for _, start := range order {
+		if !edges[start] {
+			continue
+		}
+
+		loop := []geom.Vector{}
+		for e := start; edges[e]; {
+			delete(edges, e)
+			loop = append(loop, geom.Vector{float64(e.from.x), float64(e.from.y)})
+
+			// turn left as much as possible, so we hug the same tiles
+			// and corners touching diagonally stay apart
+			in := vector(e)
+			best, bestTurn := edge{}, math.Inf(-1)
+			for _, next := range outgoing[e.to] {
+				if !edges[next] {
+					continue
+				}
+				out := vector(next)
+				turn := math.Atan2(geom.CrossProduct(in, out), geom.DotProduct(in, out))
+				if turn > bestTurn {
+					best, bestTurn = next, turn
+				}
+			}
+			e = best
+		}
+
+		loops = append(loops, cleanPolygon(loop))
+	}
+	return loops
+}
@@ -0,0 +1,55 @@
+package geometries
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func Test_Tilemap(t *testing.T) {
+	Convey("TileOutlines", t, func() {
+		Convey("should merge a block of tiles into one square", func() {
+			loops := TileOutlines([][]int{
+				{1, 1, 1},
+				{1, 1, 1},
+			})
+			So(loops, ShouldHaveLength, 1)
+			So(loops[0], ShouldHaveLength, 4)
+			So(PolygonArea(loops[0]), ShouldAlmostEqual, -6)
+		})
+
+		Convey("should join slopes to the ground", func() {
+			loops := TileOutlines([][]int{
+				{TILE_EMPTY, TILE_SLOPE_BR, TILE_SOLID, TILE_SLOPE_BL},
+				{TILE_SOLID, TILE_SOLID, TILE_SOLID, TILE_SOLID},
+			})
+			So(loops, ShouldHaveLength, 1)
+			// the bottom, both sides, both ramps and the top
+			So(loops[0], ShouldHaveLength, 7)
+			So(math.Abs(PolygonArea(loops[0])), ShouldAlmostEqual, 6)
+		})
+
+		Convey("should keep tiles touching by a corner apart", func() {
+			loops := TileOutlines([][]int{
+				{1, 0},
+				{0, 1},
+			})
+			So(loops, ShouldHaveLength, 2)
+			So(loops[0], ShouldHaveLength, 4)
+			So(loops[1], ShouldHaveLength, 4)
+		})
+
+		Convey("should find holes", func() {
+			loops := TileOutlines([][]int{
+				{1, 1, 1},
+				{1, 0, 1},
+				{1, 1, 1},
+			})
+			So(loops, ShouldHaveLength, 2)
+			So(PolygonArea(loops[0])*PolygonArea(loops[1]), ShouldBeLessThan, 0)
+			pieces, err := FillContours(loops)
+			So(err, ShouldBeNil)
+			So(len(pieces), ShouldBeGreaterThan, 1)
+		})
+	})
+}
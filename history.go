@@ -0,0 +1,94 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+)
+
+// History keeps where the bodies of a world were in the last ticks,
+// so hits can be checked against what a player saw some ticks ago
+type History struct {
+	world World
+	ticks []historyTick
+
+	recordC func(interface{})
+}
+
+type historyTick struct {
+	tick       uint64
+	ok         bool
+	bodies     []bodies.Body
+	transforms []historyTransform
+}
+
+type historyTransform struct {
+	pos   geom.Vector
+	angle float64
+}
+
+// NewHistory records the world after every timestep, keeping size ticks
+func NewHistory(w World, size int) *History {
+	h := &History{world: w, ticks: make([]historyTick, size)}
+	h.recordC = func(interface{}) { h.Record() }
+	w.On("tick", &h.recordC)
+	h.Record()
+	return h
+}
+
+// Stop stops recording
+func (h *History) Stop() { h.world.Off("tick", &h.recordC) }
+
+// Record saves the transforms of the bodies at the current tick.
+// It is called after every timestep
+func (h *History) Record() {
+	tick := h.world.Tick()
+	t := &h.ticks[tick%uint64(len(h.ticks))]
+	t.tick, t.ok = tick, true
+	t.bodies = append(t.bodies[:0], h.world.Bodies()...)
+	t.transforms = t.transforms[:0]
+	for _, body := range t.bodies {
+		state := body.State()
+		t.transforms = append(t.transforms, historyTransform{state.Pos, state.Angular.Pos})
+	}
+}
+
+// at moves the bodies there were at the tick to where they were,
+// runs the query and puts them back
+func (h *History) at(tick uint64, query func(list []bodies.Body)) error {
+	t := &h.ticks[tick%uint64(len(h.ticks))]
+	if !t.ok || t.tick != tick {
+		return ERROR_TICK_OUT_OF_RANGE
+	}
+
+	live := make([]historyTransform, len(t.bodies))
+	for i, body := range t.bodies {
+		state := body.State()
+		live[i] = historyTransform{state.Pos, state.Angular.Pos}
+		state.Pos, state.Angular.Pos = t.transforms[i].pos, t.transforms[i].angle
+	}
+	defer func() {
+		for i, body := range t.bodies {
+			state := body.State()
+			state.Pos, state.Angular.Pos = live[i].pos, live[i].angle
+		}
+	}()
+
+	query(t.bodies)
+	return nil
+}
+
+// RayCastAt is World.RayCast with the bodies as they were at the tick
+func (h *History) RayCastAt(tick uint64, from, to geom.Vector) (hit RayCastHit, ok bool, err error) {
+	err = h.at(tick, func(list []bodies.Body) {
+		hit, ok = rayCast(list, from, to)
+	})
+	return
+}
+
+// QueryAABBAt is World.QueryAABB with the bodies as they were at the tick
+func (h *History) QueryAABBAt(tick uint64, box geom.AABB) (found []bodies.Body, err error) {
+	err = h.at(tick, func(list []bodies.Body) {
+		found = queryAABB(list, box)
+	})
+	return
+}
@@ -0,0 +1,88 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func Test_History(t *testing.T) {
+	Convey("History", t, func() {
+		w := NewWorldLockstep()
+		target := bodies.NewCircle(1)
+		target.SetPosition(0, 10)
+		target.SetVelocity(0.1, 0)
+		w.Add(target)
+
+		history := NewHistory(w, 16)
+		for i := 0; i < 20; i++ {
+			w.StepTick()
+		}
+		live := *target.State()
+
+		Convey("should hit the body where it was", func() {
+			x := -100.0
+			for _, t := range []uint64{4, 8, 12} {
+				before := x
+				hit, ok, err := history.RayCastAt(t+8, geom.Vector{-100, 10}, geom.Vector{100, 10})
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(hit.Body, ShouldEqual, target)
+				x = hit.Point.X
+				So(x, ShouldBeGreaterThan, before)
+			}
+			So(target.State().Pos, ShouldResemble, live.Pos)
+
+			// it has moved since
+			old, _ := history.QueryAABBAt(5, geom.AABB{X: 0, Y: 10, HW: 0.5, HH: 0.5})
+			So(old, ShouldBeEmpty)
+			perTick := live.Pos.X / 20
+			old, _ = history.QueryAABBAt(5, geom.AABB{X: perTick * 5, Y: 10, HW: 0.5, HH: 0.5})
+			So(old, ShouldHaveLength, 1)
+			now := w.QueryAABB(geom.AABB{X: live.Pos.X, Y: 10, HW: 0.5, HH: 0.5})
+			So(now, ShouldHaveLength, 1)
+		})
+
+		Convey("should only know the last ticks", func() {
+			_, _, err := history.RayCastAt(3, geom.Vector{}, geom.Vector{1, 1})
+			So(err, ShouldEqual, ERROR_TICK_OUT_OF_RANGE)
+			_, err = history.QueryAABBAt(21, geom.AABB{})
+			So(err, ShouldEqual, ERROR_TICK_OUT_OF_RANGE)
+		})
+
+		Convey("should record every tick of a Step", func() {
+			from := w.Tick()
+			// the first Step only starts the clock
+			start := time.Unix(0, 0)
+			w.Step(start)
+			w.Step(start.Add(time.Second / 20))
+			So(w.Tick(), ShouldBeGreaterThan, from+1)
+
+			x := -100.0
+			for t := from + 1; t <= w.Tick(); t++ {
+				before := x
+				hit, ok, err := history.RayCastAt(t, geom.Vector{-100, 10}, geom.Vector{100, 10})
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				x = hit.Point.X
+				So(x, ShouldBeGreaterThan, before)
+			}
+		})
+
+		Convey("should leave out bodies that weren't there", func() {
+			late := bodies.NewCircle(1)
+			late.SetPosition(-50, 10)
+			w.Add(late)
+			w.StepTick()
+
+			hit, ok, err := history.RayCastAt(20, geom.Vector{-100, 10}, geom.Vector{100, 10})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(hit.Body, ShouldEqual, target)
+			hit, _, _ = history.RayCastAt(21, geom.Vector{-100, 10}, geom.Vector{100, 10})
+			So(hit.Body, ShouldEqual, late)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package integrators
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/fixed"
+	"github.com/oniproject/physics.go/geom"
+	"time"
+)
+
+// FixedEuler is ImprovedEuler done in Q32.32 fixed point. The body states
+// are still stored as float64, but every step reads them as fixed-point
+// numbers and writes the results back on the Q32.32 grid, which float64
+// holds exactly while the values stay below 2^21
+type FixedEuler struct {
+	Drag fixed.Fixed
+}
+
+func NewFixedEuler() Integrator {
+	return &FixedEuler{}
+}
+
+func (this *FixedEuler) SetWorld(world World) {}
+
+func (this *FixedEuler) IntegrateVelocities(things []bodies.Body, dt time.Duration) {
+	drag := fixed.One - this.Drag
+	seconds := fixedSeconds(dt)
+
+	for _, body := range things {
+		state := body.State()
+
+		if body.Treatment() == bodies.TREATMENT_STATIC {
+			state.Vel = geom.Vector{}
+			state.Acc = geom.Vector{}
+			state.Angular.Vel = 0
+			state.Angular.Acc = 0
+			continue
+		}
+
+		if body.Asleep() {
+			state.Acc = geom.Vector{}
+			state.Angular.Acc = 0
+			continue
+		}
+
+		if body.Treatment() == bodies.TREATMENT_KINEMATIC {
+			state.Old.Vel = state.Vel
+			state.Old.Acc = geom.Vector{}
+			state.Acc = geom.Vector{}
+			state.Old.Angular.Vel = state.Angular.Vel
+			state.Angular.Acc = 0
+			continue
+		}
+
+		vel, acc := fixed.VectorFrom(state.Vel), fixed.VectorFrom(state.Acc)
+
+		state.Old.Vel = vel.Float()
+		state.Old.Acc = acc.Float()
+
+		vel = vel.Plus(acc.Times(seconds))
+		if drag != fixed.One {
+			vel = vel.Times(drag)
+		}
+		state.Vel = vel.Float()
+		state.Acc = geom.Vector{}
+
+		angVel := fixed.FromFloat(state.Angular.Vel)
+		state.Old.Angular.Vel = angVel.Float()
+		state.Angular.Vel = (angVel + fixed.FromFloat(state.Angular.Acc).Mul(seconds)).Float()
+		state.Angular.Acc = 0
+	}
+}
+
+func (this *FixedEuler) IntegratePositions(things []bodies.Body, dt time.Duration) {
+	seconds := fixedSeconds(dt)
+	halfdtdt := seconds.Mul(seconds) / 2
+
+	for _, body := range things {
+		if body.Treatment() == bodies.TREATMENT_STATIC || body.Asleep() {
+			continue
+		}
+
+		state := body.State()
+
+		pos := fixed.VectorFrom(state.Pos)
+		state.Old.Pos = pos.Float()
+		vel, acc := fixed.VectorFrom(state.Old.Vel), fixed.VectorFrom(state.Old.Acc)
+		pos = pos.Plus(vel.Times(seconds)).Plus(acc.Times(halfdtdt))
+		state.Old.Acc = geom.Vector{}
+
+		angle := fixed.FromFloat(state.Angular.Pos)
+		state.Old.Angular.Pos = angle.Float()
+		angVel, angAcc := fixed.FromFloat(state.Old.Angular.Vel), fixed.FromFloat(state.Old.Angular.Acc)
+		next := angle + angVel.Mul(seconds) + angAcc.Mul(halfdtdt)
+		state.Angular.Pos = next.Float()
+		state.Old.Angular.Acc = 0
+
+		// the body turns around its center of mass
+		if c := body.Centroid(); !c.Equals(geom.Vector{}) {
+			fc := fixed.VectorFrom(c)
+			before := fixed.NewTransformAngle(angle).Rotate(fc)
+			after := fixed.NewTransformAngle(next).Rotate(fc)
+			pos = pos.Plus(before.Minus(after))
+		}
+		state.Pos = pos.Float()
+	}
+}
+
+// fixedSeconds converts without going through float64
+func fixedSeconds(dt time.Duration) fixed.Fixed {
+	return fixed.FromInt(int(dt / time.Second)).Add(fixed.FromInt(int(dt % time.Second)).Div(fixed.FromInt(int(time.Second))))
+}
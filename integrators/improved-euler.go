@@ -29,6 +29,25 @@ func (this *ImprovedEuler) IntegrateVelocities(things []bodies.Body, dt time.Dur
 			continue
 		}
 
+		if body.Asleep() {
+			// forget the forces, it doesn't move
+			body.State().Acc = geom.Vector{}
+			body.State().Angular.Acc = 0
+			continue
+		}
+
+		if body.Treatment() == bodies.TREATMENT_KINEMATIC {
+			// kinematic bodies keep the velocity they were given.
+			// no forces and no drag
+			state := body.State()
+			state.Old.Vel = state.Vel
+			state.Old.Acc = geom.Vector{}
+			state.Acc = geom.Vector{}
+			state.Old.Angular.Vel = state.Angular.Vel
+			state.Angular.Acc = 0
+			continue
+		}
+
 		// Inspired from https://github.com/soulwire/Coffee-Physics
 		// @licence MIT
 		//
@@ -60,7 +79,7 @@ func (this *ImprovedEuler) IntegratePositions(things []bodies.Body, dt time.Dura
 	halfdtdt := 0.5 * dt.Seconds() * dt.Seconds()
 
 	for _, body := range things {
-		if body.Treatment() == bodies.TREATMENT_STATIC {
+		if body.Treatment() == bodies.TREATMENT_STATIC || body.Asleep() {
 			continue
 		}
 
@@ -74,5 +93,13 @@ func (this *ImprovedEuler) IntegratePositions(things []bodies.Body, dt time.Dura
 		state.Old.Angular.Pos = state.Angular.Pos
 		state.Angular.Pos += state.Old.Angular.Vel*float64(dt.Seconds()) + state.Old.Angular.Acc*halfdtdt
 		state.Old.Angular.Acc = 0
+
+		// the body turns around its center of mass,
+		// so an off-center origin has to move with it
+		if c := body.Centroid(); !c.Equals(geom.Vector{}) {
+			before := geom.NewTransformAngle(state.Old.Angular.Pos).Rotate(c)
+			after := geom.NewTransformAngle(state.Angular.Pos).Rotate(c)
+			state.Pos = state.Pos.Plus(before.Minus(after))
+		}
 	}
 }
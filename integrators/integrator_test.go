@@ -1,8 +1,70 @@
 package integrators
 
 import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
 	"testing"
+	"time"
 )
 
 func Test_Integrators(t *testing.T) {
+	Convey("ImprovedEuler", t, func() {
+		integrator := &ImprovedEuler{Drag: 0.5}
+
+		Convey("should move kinematic bodies by their velocity only", func() {
+			body := bodies.NewCircle(1)
+			body.SetTreatment(bodies.TREATMENT_KINEMATIC)
+			body.SetVelocity(2, 0)
+			body.State().Acc = geom.Vector{0, 10}
+			body.State().Angular.Vel = 1
+
+			things := []bodies.Body{body}
+			integrator.IntegrateVelocities(things, time.Second)
+			integrator.IntegratePositions(things, time.Second)
+
+			So(body.State().Vel, ShouldResemble, geom.Vector{2, 0})
+			So(body.State().Pos, ShouldResemble, geom.Vector{2, 0})
+			So(body.State().Angular.Pos, ShouldEqual, 1)
+		})
+
+		Convey("should rotate bodies around their center of mass", func() {
+			body := bodies.NewSegment(geom.Vector{1, -1}, geom.Vector{1, 1})
+			body.SetTreatment(bodies.TREATMENT_DYNAMIC)
+			body.State().Angular.Vel = math.Pi
+
+			things := []bodies.Body{body}
+			integrator.IntegrateVelocities(things, time.Second)
+			integrator.IntegratePositions(things, time.Second)
+
+			// the centroid (1, 0) stays in place
+			pos := body.State().Pos
+			So(pos.X, ShouldAlmostEqual, 2)
+			So(pos.Y, ShouldAlmostEqual, 0)
+		})
+	})
+
+	Convey("FixedEuler", t, func() {
+		Convey("should follow ImprovedEuler", func() {
+			float, fixed := bodies.NewCircle(1), bodies.NewCircle(1)
+			for _, body := range []bodies.Body{float, fixed} {
+				body.SetVelocity(1.5, -2)
+				body.State().Angular.Vel = 0.25
+			}
+
+			for i := 0; i < 100; i++ {
+				float.State().Acc = geom.Vector{0, 0.5}
+				fixed.State().Acc = geom.Vector{0, 0.5}
+				NewImprovedEuler().IntegrateVelocities([]bodies.Body{float}, time.Second/10)
+				NewImprovedEuler().IntegratePositions([]bodies.Body{float}, time.Second/10)
+				NewFixedEuler().IntegrateVelocities([]bodies.Body{fixed}, time.Second/10)
+				NewFixedEuler().IntegratePositions([]bodies.Body{fixed}, time.Second/10)
+			}
+
+			So(fixed.State().Pos.X, ShouldAlmostEqual, float.State().Pos.X, 1e-6)
+			So(fixed.State().Pos.Y, ShouldAlmostEqual, float.State().Pos.Y, 1e-6)
+			So(fixed.State().Angular.Pos, ShouldAlmostEqual, float.State().Angular.Pos, 1e-6)
+		})
+	})
 }
@@ -0,0 +1,79 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/integrators"
+	"github.com/oniproject/physics.go/util"
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// NewWorldLockstep returns a world for lockstep games.
+// It is meant to be stepped with StepTick instead of Step.
+//
+// Every client has to add the same bodies and behaviors in the same order.
+// The world integrates with integrators.FixedEuler, so the body states stay
+// on the Q32.32 grid. To get the same results on every architecture collide
+// with behaviors.NewFixedBodyCollisionDetection and
+// behaviors.NewFixedBodyImpulseResponse, which handle circles, rectangles
+// and convex polygons, and only add ConstantAcceleration besides them.
+// The other behaviors and geometries use float64 math and give the same
+// results only on the same architecture
+func NewWorldLockstep() World {
+	w := &world{
+		kind:   "lockstep",
+		maxIPF: 16,
+		PubSub: util.NewPubSub(),
+		warp:   1,
+	}
+	w.SetIntegrator(integrators.NewFixedEuler())
+	w.SetTimeStep(time.Second / 120)
+	w.listen()
+	return w
+}
+
+// StepTick advances the world by exactly one timestep,
+// without looking at the clock
+func (w *world) StepTick() {
+	if w.paused {
+		return
+	}
+	w.tick++
+	w.time = w.time.Add(w.dt)
+	w.Itertate(w.dt * 1000)
+	w.Emit("step", w.meta)
+}
+
+// Tick returns the number of timesteps taken by Step and StepTick
+func (w *world) Tick() uint64 { return w.tick }
+
+// Checksum hashes the state of the bodies, in their order, and the tick.
+// Clients compare it to find out when they desync
+func (w *world) Checksum() uint64 {
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	put := func(v uint64) {
+		for i := range buf {
+			buf[i] = byte(v >> (8 * uint(i)))
+		}
+		h.Write(buf)
+	}
+	putFloat := func(v float64) { put(math.Float64bits(v)) }
+
+	put(w.tick)
+	for _, body := range w.bodies {
+		state := body.State()
+		putFloat(state.Pos.X)
+		putFloat(state.Pos.Y)
+		putFloat(state.Vel.X)
+		putFloat(state.Vel.Y)
+		putFloat(state.Angular.Pos)
+		putFloat(state.Angular.Vel)
+		if body.Asleep() {
+			put(1)
+		} else {
+			put(0)
+		}
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,112 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/fixed"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func Test_Lockstep(t *testing.T) {
+	Convey("Lockstep", t, func() {
+		run := func(ticks int) (w World, sums []uint64) {
+			w = NewWorldLockstep()
+			w.Add(
+				behaviors.NewConstantAcceleration(0, 0.0004),
+				behaviors.NewSweepPrune(),
+				behaviors.NewBodyCollisionDetection(),
+				behaviors.NewBodyImpulseResponse(),
+			)
+			ground := bodies.NewRectangle(200, 10)
+			ground.SetPosition(0, 40)
+			ground.SetTreatment(bodies.TREATMENT_STATIC)
+			w.Add(ground)
+			for i := 0; i < 6; i++ {
+				box := bodies.NewRectangle(4, 4)
+				box.SetPosition(float64(i)*3.5, 20-float64(i)*5)
+				box.State().Angular.Vel = 0.0005 * float64(i)
+				w.Add(box)
+			}
+			for i := 0; i < ticks; i++ {
+				w.StepTick()
+				sums = append(sums, w.Checksum())
+			}
+			return
+		}
+
+		a, sumsA := run(200)
+		b, sumsB := run(200)
+
+		Convey("should number the bodies per world", func() {
+			for i, body := range a.Bodies() {
+				So(body.UID(), ShouldEqual, int64(i+1))
+				So(b.Bodies()[i].UID(), ShouldEqual, body.UID())
+			}
+		})
+
+		Convey("should give the same checksums every run", func() {
+			So(a.Tick(), ShouldEqual, 200)
+			So(sumsB, ShouldResemble, sumsA)
+			So(sumsA[0], ShouldNotEqual, sumsA[199])
+		})
+
+		Convey("should count the timesteps of Step too", func() {
+			w := NewWorldImprovedEuler()
+			w.SetTimeStep(10 * time.Millisecond)
+			w.Add(bodies.NewCircle(1), bodies.NewCircle(1))
+			So(w.Bodies()[1].UID(), ShouldEqual, 2)
+
+			now := time.Now()
+			w.Step(now)
+			w.Step(now.Add(30 * time.Millisecond))
+			So(w.Tick(), ShouldEqual, 3)
+		})
+
+		Convey("should change the checksum when a body moves", func() {
+			sum := a.Checksum()
+			a.Bodies()[1].State().Pos.X += 1e-12
+			So(a.Checksum(), ShouldNotEqual, sum)
+		})
+
+		Convey("should run the fixed-point pipeline", func() {
+			w := NewWorldLockstep()
+			w.Add(
+				behaviors.NewConstantAcceleration(0, 0.0004),
+				behaviors.NewFixedBodyCollisionDetection(),
+				behaviors.NewFixedBodyImpulseResponse(),
+			)
+			ground := bodies.NewRectangle(200, 10)
+			ground.SetPosition(0, 40)
+			ground.SetTreatment(bodies.TREATMENT_STATIC)
+			ground.SetRestitution(0)
+			w.Add(ground)
+			for i := 0; i < 3; i++ {
+				box := bodies.NewRectangle(4, 4)
+				box.SetPosition(float64(i)*10, 20-float64(i)*5)
+				box.State().Angular.Vel = 0.0005 * float64(i)
+				ball := bodies.NewCircle(2)
+				ball.SetPosition(float64(i)*10+5, 10)
+				tri := bodies.NewConvexPolygon([]geom.Vector{{-2, -1}, {2, -1}, {0, 2}})
+				tri.SetPosition(float64(i)*10-5, 0)
+				w.Add(box, ball, tri)
+			}
+			for i := 0; i < 600; i++ {
+				w.StepTick()
+			}
+
+			for _, body := range w.Bodies()[1:] {
+				state := body.State()
+				// resting on the ground, on the Q32.32 grid
+				So(state.Pos.Y, ShouldBeBetween, 30, 35)
+				for _, v := range []float64{state.Pos.X, state.Pos.Y, state.Vel.X, state.Vel.Y, state.Angular.Pos, state.Angular.Vel} {
+					So(fixed.FromFloat(v).Float(), ShouldEqual, v)
+				}
+			}
+			// recorded on amd64, every architecture must get the same
+			So(w.Checksum(), ShouldEqual, uint64(0x913e94fcdcff1e9b))
+		})
+	})
+}
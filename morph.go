@@ -0,0 +1,15 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+)
+
+// Morph runs change, which resizes or reshapes the body,
+// and wakes the body and every body in contact with it
+// before or after the change
+func (w *world) Morph(body bodies.Body, change func()) {
+	w.wakeContacts(body)
+	change()
+	body.Wake()
+	w.wakeContacts(body)
+}
@@ -0,0 +1,49 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Morph(t *testing.T) {
+	Convey("Morph", t, func() {
+		world := NewWorldImprovedEuler()
+
+		ground := bodies.NewRectangle(10, 1)
+		ground.SetTreatment(bodies.TREATMENT_STATIC)
+		box := bodies.NewRectangle(1, 1)
+		box.SetPosition(0, -0.95)
+		near := bodies.NewRectangle(1, 1)
+		near.SetPosition(3, -1.2)
+		far := bodies.NewRectangle(1, 1)
+		far.SetPosition(20, 0)
+		world.Add(ground, box, near, far)
+
+		box.Sleep()
+		near.Sleep()
+		far.Sleep()
+
+		Convey("should wake the bodies touching a shrinking body", func() {
+			world.Morph(ground, func() { ground.SetScale(0.5) })
+			So(box.Asleep(), ShouldBeFalse)
+			So(near.Asleep(), ShouldBeTrue)
+			So(far.Asleep(), ShouldBeTrue)
+		})
+
+		Convey("should wake the bodies a body grows into", func() {
+			// not through Morph, the world is told by the body
+			ground.SetScale(2)
+			So(box.Asleep(), ShouldBeFalse)
+			So(near.Asleep(), ShouldBeFalse)
+			So(far.Asleep(), ShouldBeTrue)
+		})
+
+		Convey("should not be told about removed bodies", func() {
+			world.Remove(ground)
+			ground.SetScale(2)
+			So(box.Asleep(), ShouldBeTrue)
+			So(near.Asleep(), ShouldBeTrue)
+		})
+	})
+}
@@ -1,3 +1,65 @@
 package physics
 
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+)
+
 type Query struct{}
+
+// RayCastHit is the closest body a ray hit.
+// Point and Norm are in world coordinates
+type RayCastHit struct {
+	Body bodies.Body
+	geometries.RayHit
+}
+
+// toLocal moves a world point into the body's coordinates
+func toLocal(body bodies.Body, pt geom.Vector) geom.Vector {
+	state := body.State()
+	return geom.NewTransformAngle(state.Angular.Pos).RotateInv(pt.Minus(state.Pos))
+}
+
+// QueryPoint returns the bodies that contain the point
+func (w *world) QueryPoint(pt geom.Vector) (found []bodies.Body) {
+	for _, body := range w.bodies {
+		if !geom.AABBcontains(body.AABB(body.State().Angular.Pos), pt) {
+			continue
+		}
+		if body.Geometry().ContainsPoint(toLocal(body, pt)) {
+			found = append(found, body)
+		}
+	}
+	return
+}
+
+// QueryAABB returns the bodies whose AABB overlaps the box
+func (w *world) QueryAABB(box geom.AABB) []bodies.Body { return queryAABB(w.bodies, box) }
+
+func queryAABB(list []bodies.Body, box geom.AABB) (found []bodies.Body) {
+	for _, body := range list {
+		if geom.AABBoverlap(body.AABB(body.State().Angular.Pos), box) {
+			found = append(found, body)
+		}
+	}
+	return
+}
+
+// RayCast returns the first body on the way from one point to another
+func (w *world) RayCast(from, to geom.Vector) (RayCastHit, bool) { return rayCast(w.bodies, from, to) }
+
+func rayCast(list []bodies.Body, from, to geom.Vector) (hit RayCastHit, ok bool) {
+	for _, body := range list {
+		h, hitBody := body.Geometry().RayCast(toLocal(body, from), toLocal(body, to))
+		if !hitBody || (ok && h.Fraction >= hit.Fraction) {
+			continue
+		}
+
+		trans := geom.NewTransformAngle(body.State().Angular.Pos)
+		h.Point = trans.Rotate(h.Point).Plus(body.State().Pos)
+		h.Norm = trans.Rotate(h.Norm)
+		hit, ok = RayCastHit{Body: body, RayHit: h}, true
+	}
+	return
+}
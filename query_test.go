@@ -0,0 +1,44 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Query(t *testing.T) {
+	Convey("Query", t, func() {
+		world := NewWorldImprovedEuler()
+
+		circle := bodies.NewCircle(1)
+		circle.SetPosition(0, 0)
+		box := bodies.NewRectangle(2, 2)
+		box.SetPosition(5, 0)
+		box.State().Angular.Pos = 0.3
+		world.Add(circle, box)
+
+		Convey("should find the bodies under a point", func() {
+			So(world.QueryPoint(geom.Vector{0.5, 0}), ShouldResemble, []bodies.Body{circle})
+			So(world.QueryPoint(geom.Vector{5, 0.5}), ShouldResemble, []bodies.Body{box})
+			So(world.QueryPoint(geom.Vector{2.5, 0}), ShouldBeEmpty)
+		})
+
+		Convey("should find the bodies in a box", func() {
+			So(world.QueryAABB(geom.NewAABB_byMM(-2, -2, 2, 2)), ShouldResemble, []bodies.Body{circle})
+			So(world.QueryAABB(geom.NewAABB_byMM(-2, -2, 10, 2)), ShouldHaveLength, 2)
+		})
+
+		Convey("should hit the first body on a ray", func() {
+			hit, ok := world.RayCast(geom.Vector{10, 0}, geom.Vector{-10, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Body, ShouldEqual, box)
+			So(hit.Norm.X, ShouldBeGreaterThan, 0)
+
+			hit, ok = world.RayCast(geom.Vector{-10, 0}, geom.Vector{10, 0})
+			So(ok, ShouldBeTrue)
+			So(hit.Body, ShouldEqual, circle)
+			So(hit.Point.X, ShouldAlmostEqual, -1)
+		})
+	})
+}
@@ -0,0 +1,342 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/fixed"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	"github.com/oniproject/physics.go/integrators"
+	"github.com/oniproject/physics.go/util"
+	"math"
+)
+
+var ERROR_REGISTERED = util.ERROR_REGISTERED
+var ERROR_BAD_OPTIONS = util.ERROR_BAD_OPTIONS
+var ERROR_BAD_FACTORY = util.ERROR_BAD_FACTORY
+
+type BehaviorFactory func(options interface{}) (behaviors.Behavior, error)
+type IntegratorFactory func(options interface{}) (integrators.Integrator, error)
+type GeometryFactory func(options interface{}) (geometries.Geometry, error)
+
+// the describers return the options that make the component again,
+// ok is false when it is not of their type. scenes save components with them
+type BehaviorDescriber func(b behaviors.Behavior) (options interface{}, ok bool)
+type IntegratorDescriber func(i integrators.Integrator) (options interface{}, ok bool)
+type GeometryDescriber func(g geometries.Geometry) (options interface{}, ok bool)
+
+var behaviorRegistry = util.NewRegistry(ERROR_UNKNOWN_BEHAVIOR)
+var integratorRegistry = util.NewRegistry(ERROR_UNKNOWN_INTEGRATOR)
+
+// RegisterBehavior adds a behavior by name. defaults returns a pointer to
+// a new options struct with the default values, the factory gets one like it.
+// describe can be nil, then scenes can load the behavior but not save it.
+// The options are written to scenes with encoding/json
+func RegisterBehavior(name string, defaults func() interface{}, factory BehaviorFactory, describe BehaviorDescriber) error {
+	if factory == nil {
+		return ERROR_BAD_FACTORY
+	}
+	var d util.Describer
+	if describe != nil {
+		d = func(c interface{}) (interface{}, bool) {
+			b, ok := c.(behaviors.Behavior)
+			if !ok {
+				return nil, false
+			}
+			return describe(b)
+		}
+	}
+	return behaviorRegistry.Register(name, defaults, func(options interface{}) (interface{}, error) {
+		return factory(options)
+	}, d)
+}
+
+// NewBehavior makes a registered behavior. options can be nil for the defaults
+func NewBehavior(name string, options interface{}) (behaviors.Behavior, error) {
+	b, err := behaviorRegistry.Create(name, options)
+	if err != nil {
+		return nil, err
+	}
+	return b.(behaviors.Behavior), nil
+}
+
+// NewBehaviorOptions returns the default options of a behavior to fill in
+func NewBehaviorOptions(name string) (interface{}, error) { return behaviorRegistry.Options(name) }
+func BehaviorNames() []string                             { return behaviorRegistry.Names() }
+
+func RegisterIntegrator(name string, defaults func() interface{}, factory IntegratorFactory, describe IntegratorDescriber) error {
+	if factory == nil {
+		return ERROR_BAD_FACTORY
+	}
+	var d util.Describer
+	if describe != nil {
+		d = func(c interface{}) (interface{}, bool) {
+			i, ok := c.(integrators.Integrator)
+			if !ok {
+				return nil, false
+			}
+			return describe(i)
+		}
+	}
+	return integratorRegistry.Register(name, defaults, func(options interface{}) (interface{}, error) {
+		return factory(options)
+	}, d)
+}
+
+func NewIntegrator(name string, options interface{}) (integrators.Integrator, error) {
+	i, err := integratorRegistry.Create(name, options)
+	if err != nil {
+		return nil, err
+	}
+	return i.(integrators.Integrator), nil
+}
+
+func NewIntegratorOptions(name string) (interface{}, error) { return integratorRegistry.Options(name) }
+func IntegratorNames() []string                             { return integratorRegistry.Names() }
+
+// RegisterGeometry adds a geometry to the registry of the geometries package,
+// which bodies are decoded with
+func RegisterGeometry(name string, defaults func() interface{}, factory GeometryFactory, describe GeometryDescriber) error {
+	if factory == nil {
+		return ERROR_BAD_FACTORY
+	}
+	var d util.Describer
+	if describe != nil {
+		d = func(c interface{}) (interface{}, bool) {
+			g, ok := c.(geometries.Geometry)
+			if !ok {
+				return nil, false
+			}
+			return describe(g)
+		}
+	}
+	return geometries.RegisterGeometry(name, defaults, func(options interface{}) (interface{}, error) {
+		return factory(options)
+	}, d)
+}
+
+func NewGeometry(name string, options interface{}) (geometries.Geometry, error) {
+	return geometries.NewGeometry(name, options)
+}
+
+func NewGeometryOptions(name string) (interface{}, error) { return geometries.NewGeometryOptions(name) }
+func GeometryNames() []string                             { return geometries.GeometryNames() }
+
+// options of the built-in behaviors
+
+type ConstantAccelerationOptions struct {
+	Acc geom.Vector `json:"acc"`
+}
+
+type NewtonianOptions struct {
+	Strength float64    `json:"strength"`
+	MinSq    *float64   `json:"minSq,omitempty"` // nil is 100 * Strength
+	MaxSq    SceneFloat `json:"maxSq"`
+}
+
+type AttractorOptions struct {
+	Pos      geom.Vector `json:"pos"`
+	Strength float64     `json:"strength"`
+	Order    float64     `json:"order"`
+	Min      SceneFloat  `json:"min"`
+	Max      SceneFloat  `json:"max"`
+}
+
+// EdgeCollisionDetectionOptions has the corners of the edges
+type EdgeCollisionDetectionOptions struct {
+	Channel     string      `json:"channel"`
+	Min         geom.Vector `json:"min"`
+	Max         geom.Vector `json:"max"`
+	Cof         float64     `json:"cof"`
+	Restitution float64     `json:"restitution"`
+}
+
+type ChannelOptions struct {
+	Channel string `json:"channel"`
+}
+
+type BodyCollisionDetectionOptions struct {
+	Check   string `json:"check"`
+	Channel string `json:"channel"`
+}
+
+// options of the built-in integrators
+
+type ImprovedEulerOptions struct {
+	Drag float64 `json:"drag"`
+}
+
+func init() {
+	RegisterBehavior("constant-acceleration",
+		func() interface{} { return &ConstantAccelerationOptions{Acc: geom.Vector{0, 0.0004}} },
+		func(options interface{}) (behaviors.Behavior, error) {
+			o := options.(*ConstantAccelerationOptions)
+			return behaviors.NewConstantAcceleration(o.Acc.X, o.Acc.Y), nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			c, ok := b.(*behaviors.ConstantAcceleration)
+			if !ok {
+				return nil, false
+			}
+			return &ConstantAccelerationOptions{c.Acc}, true
+		})
+	RegisterBehavior("newtonian",
+		func() interface{} { return &NewtonianOptions{Strength: 1, MaxSq: SceneFloat(math.Inf(1))} },
+		func(options interface{}) (behaviors.Behavior, error) {
+			o := options.(*NewtonianOptions)
+			b := behaviors.NewNewtonian(o.Strength).(*behaviors.Newtonian)
+			minSq, _ := b.SquaredLimits()
+			if o.MinSq != nil {
+				minSq = *o.MinSq
+			}
+			b.SetSquaredLimits(minSq, float64(o.MaxSq))
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			n, ok := b.(*behaviors.Newtonian)
+			if !ok {
+				return nil, false
+			}
+			minSq, maxSq := n.SquaredLimits()
+			return &NewtonianOptions{n.Strength, &minSq, SceneFloat(maxSq)}, true
+		})
+	RegisterBehavior("attractor",
+		func() interface{} {
+			a := behaviors.NewAttractor().(*behaviors.Attractor)
+			return &AttractorOptions{a.Pos, a.Strength, a.Order, SceneFloat(a.Min), SceneFloat(a.Max)}
+		},
+		func(options interface{}) (behaviors.Behavior, error) {
+			o := options.(*AttractorOptions)
+			b := behaviors.NewAttractor().(*behaviors.Attractor)
+			b.Pos, b.Strength, b.Order = o.Pos, o.Strength, o.Order
+			b.Min, b.Max = float64(o.Min), float64(o.Max)
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			a, ok := b.(*behaviors.Attractor)
+			if !ok {
+				return nil, false
+			}
+			return &AttractorOptions{a.Pos, a.Strength, a.Order, SceneFloat(a.Min), SceneFloat(a.Max)}, true
+		})
+	RegisterBehavior("edge-collision-detection",
+		func() interface{} {
+			return &EdgeCollisionDetectionOptions{Channel: "collisions:detected", Cof: 1, Restitution: 0.99}
+		},
+		func(options interface{}) (behaviors.Behavior, error) {
+			o := options.(*EdgeCollisionDetectionOptions)
+			b := behaviors.NewEdgeCollisionDetection(geom.AABB{}, o.Cof, o.Restitution).(*behaviors.EdgeCollisionDetecton)
+			b.Channel = o.Channel
+			b.SetBounds(o.Min, o.Max)
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			e, ok := b.(*behaviors.EdgeCollisionDetecton)
+			if !ok {
+				return nil, false
+			}
+			min, max := e.Bounds()
+			return &EdgeCollisionDetectionOptions{e.Channel, min, max, e.Cof(), e.Restitution()}, true
+		})
+	RegisterBehavior("sweep-prune",
+		func() interface{} { return &ChannelOptions{Channel: "collisions:candidates"} },
+		func(options interface{}) (behaviors.Behavior, error) {
+			b := behaviors.NewSweepPrune().(*behaviors.SweepPrune)
+			b.Channel = options.(*ChannelOptions).Channel
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			s, ok := b.(*behaviors.SweepPrune)
+			if !ok {
+				return nil, false
+			}
+			return &ChannelOptions{s.Channel}, true
+		})
+	RegisterBehavior("body-collision-detection",
+		func() interface{} {
+			return &BodyCollisionDetectionOptions{Check: "collisions:candidates", Channel: "collisions:detected"}
+		},
+		func(options interface{}) (behaviors.Behavior, error) {
+			o := options.(*BodyCollisionDetectionOptions)
+			b := behaviors.NewBodyCollisionDetection().(*behaviors.BodyCollisionDetection)
+			b.Check, b.Channel = o.Check, o.Channel
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			c, ok := b.(*behaviors.BodyCollisionDetection)
+			if !ok {
+				return nil, false
+			}
+			return &BodyCollisionDetectionOptions{c.Check, c.Channel}, true
+		})
+	RegisterBehavior("body-impulse-response",
+		func() interface{} { return &ChannelOptions{Channel: "collisions:detected"} },
+		func(options interface{}) (behaviors.Behavior, error) {
+			b := behaviors.NewBodyImpulseResponse().(*behaviors.BodyImpulseResponse)
+			b.Channel = options.(*ChannelOptions).Channel
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			r, ok := b.(*behaviors.BodyImpulseResponse)
+			if !ok {
+				return nil, false
+			}
+			return &ChannelOptions{r.Channel}, true
+		})
+	RegisterBehavior("fixed-body-collision-detection",
+		func() interface{} { return &ChannelOptions{Channel: "collisions:detected"} },
+		func(options interface{}) (behaviors.Behavior, error) {
+			b := behaviors.NewFixedBodyCollisionDetection().(*behaviors.FixedBodyCollisionDetection)
+			b.Channel = options.(*ChannelOptions).Channel
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			c, ok := b.(*behaviors.FixedBodyCollisionDetection)
+			if !ok {
+				return nil, false
+			}
+			return &ChannelOptions{c.Channel}, true
+		})
+	RegisterBehavior("fixed-body-impulse-response",
+		func() interface{} { return &ChannelOptions{Channel: "collisions:detected"} },
+		func(options interface{}) (behaviors.Behavior, error) {
+			b := behaviors.NewFixedBodyImpulseResponse().(*behaviors.FixedBodyImpulseResponse)
+			b.Channel = options.(*ChannelOptions).Channel
+			return b, nil
+		},
+		func(b behaviors.Behavior) (interface{}, bool) {
+			r, ok := b.(*behaviors.FixedBodyImpulseResponse)
+			if !ok {
+				return nil, false
+			}
+			return &ChannelOptions{r.Channel}, true
+		})
+
+	RegisterIntegrator("improved-euler",
+		func() interface{} { return &ImprovedEulerOptions{} },
+		func(options interface{}) (integrators.Integrator, error) {
+			i := integrators.NewImprovedEuler().(*integrators.ImprovedEuler)
+			i.Drag = options.(*ImprovedEulerOptions).Drag
+			return i, nil
+		},
+		func(i integrators.Integrator) (interface{}, bool) {
+			e, ok := i.(*integrators.ImprovedEuler)
+			if !ok {
+				return nil, false
+			}
+			return &ImprovedEulerOptions{e.Drag}, true
+		})
+	RegisterIntegrator("fixed-euler",
+		func() interface{} { return &ImprovedEulerOptions{} },
+		func(options interface{}) (integrators.Integrator, error) {
+			i := integrators.NewFixedEuler().(*integrators.FixedEuler)
+			i.Drag = fixed.FromFloat(options.(*ImprovedEulerOptions).Drag)
+			return i, nil
+		},
+		func(i integrators.Integrator) (interface{}, bool) {
+			e, ok := i.(*integrators.FixedEuler)
+			if !ok {
+				return nil, false
+			}
+			return &ImprovedEulerOptions{e.Drag.Float()}, true
+		})
+}
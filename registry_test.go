@@ -0,0 +1,71 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	"github.com/oniproject/physics.go/integrators"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Registry(t *testing.T) {
+	Convey("Registry", t, func() {
+		Convey("should make the built-in components by name", func() {
+			b, err := NewBehavior("constant-acceleration", nil)
+			So(err, ShouldBeNil)
+			So(b.(*behaviors.ConstantAcceleration).Acc, ShouldResemble, geom.Vector{0, 0.0004})
+
+			b, err = NewBehavior("attractor", AttractorOptions{Strength: 5, Order: 1})
+			So(err, ShouldBeNil)
+			So(b.(*behaviors.Attractor).Strength, ShouldEqual, 5)
+
+			i, err := NewIntegrator("improved-euler", &ImprovedEulerOptions{Drag: 0.1})
+			So(err, ShouldBeNil)
+			So(i.(*integrators.ImprovedEuler).Drag, ShouldEqual, 0.1)
+
+			options, err := NewGeometryOptions("circle")
+			So(err, ShouldBeNil)
+			options.(*geometries.CircleOptions).Radius = 3
+			g, err := NewGeometry("circle", options)
+			So(err, ShouldBeNil)
+			So(g.(*geometries.Circle).Radius, ShouldEqual, 3)
+
+			So(BehaviorNames(), ShouldContain, "sweep-prune")
+			So(GeometryNames(), ShouldContain, "heightfield")
+		})
+
+		Convey("should return errors instead of panicking", func() {
+			_, err := NewBehavior("magic", nil)
+			So(err, ShouldEqual, ERROR_UNKNOWN_BEHAVIOR)
+			_, err = NewIntegrator("verlet", nil)
+			So(err, ShouldEqual, ERROR_UNKNOWN_INTEGRATOR)
+			_, err = NewGeometryOptions("blob")
+			So(err, ShouldEqual, geometries.ERROR_UNKNOWN_GEOMETRY)
+
+			_, err = NewGeometry("circle", geometries.RectangleOptions{})
+			So(err, ShouldEqual, ERROR_BAD_OPTIONS)
+			_, err = NewGeometry("convex-polygon", geometries.PolygonOptions{Vertices: []geom.Vector{{0, 0}, {2, 0}, {1, 1}, {2, 2}, {0, 2}}})
+			So(err, ShouldEqual, geometries.ERROR_NOT_CONVEX)
+		})
+
+		Convey("should register user components", func() {
+			type SpinOptions struct{ Speed float64 }
+			err := RegisterBehavior("test-spin",
+				func() interface{} { return &SpinOptions{Speed: 2} },
+				func(options interface{}) (behaviors.Behavior, error) {
+					return behaviors.NewConstantAcceleration(options.(*SpinOptions).Speed, 0), nil
+				}, nil)
+			So(err, ShouldBeNil)
+
+			b, err := NewBehavior("test-spin", nil)
+			So(err, ShouldBeNil)
+			So(b.(*behaviors.ConstantAcceleration).Acc.X, ShouldEqual, 2)
+
+			So(RegisterBehavior("test-spin", func() interface{} { return &SpinOptions{} }, func(interface{}) (behaviors.Behavior, error) { return nil, nil }, nil), ShouldEqual, ERROR_REGISTERED)
+			So(RegisterBehavior("test-nil", nil, nil, nil), ShouldEqual, ERROR_BAD_FACTORY)
+			So(RegisterIntegrator("test-nil", func() interface{} { return &struct{}{} }, nil, nil), ShouldEqual, ERROR_BAD_FACTORY)
+			So(RegisterGeometry("test-nil", nil, func(interface{}) (geometries.Geometry, error) { return nil, nil }, nil), ShouldEqual, ERROR_BAD_FACTORY)
+		})
+	})
+}
@@ -0,0 +1,444 @@
+package physics
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	"io"
+	"math"
+)
+
+// A replay file starts with REPLAY_MAGIC, the version, the tick,
+// the kind of the world and the scene as JSON. Then come the records,
+// each one is an op byte and its arguments. All numbers are little endian
+const (
+	REPLAY_MAGIC   = "PHRP"
+	REPLAY_VERSION = 2
+)
+
+const (
+	OP_TICK            = 1 // checksum uint64
+	OP_ADD_BODY        = 2 // body JSON
+	OP_REMOVE_BODY     = 3 // body uint32
+	OP_SET_POSITION    = 4 // body uint32, x, y float64
+	OP_SET_VELOCITY    = 5 // body uint32, x, y float64
+	OP_APPLY_FORCE     = 6 // body uint32, force x, y, point x, y float64
+	OP_ADD_BEHAVIOR    = 7 // behavior JSON, the targets are body numbers
+	OP_REMOVE_BEHAVIOR = 8 // the index in World.Behaviors() uint32
+)
+
+var ERROR_REPLAY_FORMAT = errors.New("Error: Not a replay file.")
+var ERROR_UNKNOWN_BODY = errors.New("Error: The body is not in the world.")
+var ERROR_UNKNOWN_WORLD = errors.New("Error: Unknown world type.")
+
+// the constructors of the worlds by kind
+var worldKinds = map[string]func() World{
+	"improved-euler": NewWorldImprovedEuler,
+	"lockstep":       NewWorldLockstep,
+}
+
+// DesyncError is returned by the player when a tick
+// doesn't end with the recorded checksum
+type DesyncError struct {
+	Tick          uint64
+	Recorded, Got uint64
+}
+
+func (e *DesyncError) Error() string {
+	return fmt.Sprintf("Error: Desync at tick %d, checksum %x instead of %x.", e.Tick, e.Got, e.Recorded)
+}
+
+// Recorder makes the changes to a world and writes them down.
+// Everything that happens to the world from outside has to go
+// through it, and the world must be stepped with Step.
+// Bodies are numbered in the order they appear, so the player
+// can find them again.
+//
+// Changes to the fields of a behavior in the world are not recorded,
+// a behavior with other parameters has to be put in with ReplaceBehavior
+type Recorder struct {
+	world  World
+	out    *bufio.Writer
+	bodies map[bodies.Body]uint32
+	next   uint32
+}
+
+// NewRecorder writes the world as it is now
+func NewRecorder(w World, out io.Writer) (*Recorder, error) {
+	kind := ""
+	if w, ok := w.(*world); ok {
+		kind = w.kind
+	}
+	if _, ok := worldKinds[kind]; !ok {
+		return nil, ERROR_UNKNOWN_WORLD
+	}
+
+	scene, err := encodeScene(w)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(scene)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{world: w, out: bufio.NewWriter(out), bodies: map[bodies.Body]uint32{}}
+	for _, body := range w.Bodies() {
+		r.number(body)
+	}
+
+	r.out.WriteString(REPLAY_MAGIC)
+	r.put(uint16(REPLAY_VERSION), w.Tick())
+	r.putBytes([]byte(kind))
+	r.putBytes(data)
+	return r, nil
+}
+
+func (r *Recorder) World() World { return r.world }
+
+func (r *Recorder) AddBody(body bodies.Body) error {
+	d, err := bodies.EncodeBody(body)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	r.world.AddBody(body)
+	r.number(body)
+	r.put(uint8(OP_ADD_BODY))
+	r.putBytes(data)
+	return nil
+}
+
+func (r *Recorder) RemoveBody(body bodies.Body) error {
+	n, ok := r.bodies[body]
+	if !ok {
+		return ERROR_UNKNOWN_BODY
+	}
+	r.world.RemoveBody(body)
+	delete(r.bodies, body)
+	r.put(uint8(OP_REMOVE_BODY), n)
+	return nil
+}
+
+func (r *Recorder) SetPosition(body bodies.Body, x, y float64) error {
+	n, ok := r.bodies[body]
+	if !ok {
+		return ERROR_UNKNOWN_BODY
+	}
+	body.SetPosition(x, y)
+	r.put(uint8(OP_SET_POSITION), n, x, y)
+	return nil
+}
+
+func (r *Recorder) SetVelocity(body bodies.Body, x, y float64) error {
+	n, ok := r.bodies[body]
+	if !ok {
+		return ERROR_UNKNOWN_BODY
+	}
+	body.SetVelocity(x, y)
+	r.put(uint8(OP_SET_VELOCITY), n, x, y)
+	return nil
+}
+
+func (r *Recorder) ApplyForce(body bodies.Body, force, p geom.Vector) error {
+	n, ok := r.bodies[body]
+	if !ok {
+		return ERROR_UNKNOWN_BODY
+	}
+	body.ApplyForce(force, p)
+	r.put(uint8(OP_APPLY_FORCE), n, force.X, force.Y, p.X, p.Y)
+	return nil
+}
+
+// AddBehavior takes registered behaviors that can be described
+func (r *Recorder) AddBehavior(behavior behaviors.Behavior) error {
+	d, err := encodeBehavior(behavior, func(body bodies.Body) (int, bool) {
+		n, ok := r.bodies[body]
+		return int(n), ok
+	}, ERROR_UNKNOWN_BODY)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	r.world.AddBehavior(behavior)
+	r.put(uint8(OP_ADD_BEHAVIOR))
+	r.putBytes(data)
+	return nil
+}
+
+func (r *Recorder) RemoveBehavior(behavior behaviors.Behavior) error {
+	for i, b := range r.world.Behaviors() {
+		if b == behavior {
+			r.world.RemoveBehavior(behavior)
+			r.put(uint8(OP_REMOVE_BEHAVIOR), uint32(i))
+			return nil
+		}
+	}
+	return ERROR_UNKNOWN_BEHAVIOR
+}
+
+// ReplaceBehavior removes old and adds behavior at the end,
+// this is how the parameters of a behavior are changed
+func (r *Recorder) ReplaceBehavior(old, behavior behaviors.Behavior) error {
+	// fail before anything is written
+	if _, err := encodeBehavior(behavior, func(body bodies.Body) (int, bool) {
+		n, ok := r.bodies[body]
+		return int(n), ok
+	}, ERROR_UNKNOWN_BODY); err != nil {
+		return err
+	}
+	if err := r.RemoveBehavior(old); err != nil {
+		return err
+	}
+	return r.AddBehavior(behavior)
+}
+
+// Step steps the world by one tick and writes its checksum
+func (r *Recorder) Step() error {
+	r.world.StepTick()
+	r.put(uint8(OP_TICK), r.world.Checksum())
+	return r.out.Flush()
+}
+
+func (r *Recorder) Flush() error { return r.out.Flush() }
+
+func (r *Recorder) number(body bodies.Body) {
+	r.bodies[body] = r.next
+	r.next++
+}
+
+func (r *Recorder) put(values ...interface{}) {
+	for _, v := range values {
+		binary.Write(r.out, binary.LittleEndian, v)
+	}
+}
+
+func (r *Recorder) putBytes(data []byte) {
+	r.put(uint32(len(data)))
+	r.out.Write(data)
+}
+
+// Player rebuilds a recorded world and runs it again
+type Player struct {
+	world  World
+	in     *bufio.Reader
+	bodies []bodies.Body
+}
+
+// NewPlayer builds a world of the recorded kind from the scene
+func NewPlayer(in io.Reader) (*Player, error) {
+	p := &Player{in: bufio.NewReader(in)}
+
+	magic := make([]byte, len(REPLAY_MAGIC))
+	if _, err := io.ReadFull(p.in, magic); err != nil || string(magic) != REPLAY_MAGIC {
+		return nil, ERROR_REPLAY_FORMAT
+	}
+	var version uint16
+	var tick uint64
+	if err := p.get(&version, &tick); err != nil {
+		return nil, err
+	}
+	if version != REPLAY_VERSION {
+		return nil, ERROR_REPLAY_FORMAT
+	}
+
+	kind, err := p.getBytes()
+	if err != nil {
+		return nil, err
+	}
+	newWorld, ok := worldKinds[string(kind)]
+	if !ok {
+		return nil, ERROR_UNKNOWN_WORLD
+	}
+
+	scene := &Scene{}
+	if err := p.getJSON(scene); err != nil {
+		return nil, err
+	}
+	w := newWorld()
+	if err := decodeScene(scene, w); err != nil {
+		return nil, err
+	}
+	w.(*world).tick = tick
+
+	p.world = w
+	p.bodies = append(p.bodies, w.Bodies()...)
+	return p, nil
+}
+
+func (p *Player) World() World { return p.world }
+
+// Next plays the records up to the next tick and checks the checksum.
+// It returns io.EOF at the end of the replay
+func (p *Player) Next() error {
+	for {
+		op, err := p.in.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		switch op {
+		case OP_TICK:
+			var recorded uint64
+			if err := p.get(&recorded); err != nil {
+				return err
+			}
+			p.world.StepTick()
+			if got := p.world.Checksum(); got != recorded {
+				return &DesyncError{Tick: p.world.Tick(), Recorded: recorded, Got: got}
+			}
+			return nil
+
+		case OP_ADD_BODY:
+			d := bodies.BodyData{}
+			if err := p.getJSON(&d); err != nil {
+				return err
+			}
+			body, err := bodies.DecodeBody(d)
+			if err != nil {
+				return err
+			}
+			p.world.AddBody(body)
+			p.bodies = append(p.bodies, body)
+
+		case OP_REMOVE_BODY:
+			body, err := p.body()
+			if err != nil {
+				return err
+			}
+			p.world.RemoveBody(body)
+
+		case OP_SET_POSITION, OP_SET_VELOCITY:
+			body, err := p.body()
+			if err != nil {
+				return err
+			}
+			var x, y float64
+			if err := p.get(&x, &y); err != nil {
+				return err
+			}
+			if op == OP_SET_POSITION {
+				body.SetPosition(x, y)
+			} else {
+				body.SetVelocity(x, y)
+			}
+
+		case OP_APPLY_FORCE:
+			body, err := p.body()
+			if err != nil {
+				return err
+			}
+			var force, point geom.Vector
+			if err := p.get(&force.X, &force.Y, &point.X, &point.Y); err != nil {
+				return err
+			}
+			body.ApplyForce(force, point)
+
+		case OP_ADD_BEHAVIOR:
+			d := BehaviorData{}
+			if err := p.getJSON(&d); err != nil {
+				return err
+			}
+			behavior, err := decodeBehavior(d, func(n int) (bodies.Body, bool) {
+				if n < 0 || n >= len(p.bodies) {
+					return nil, false
+				}
+				return p.bodies[n], true
+			}, ERROR_UNKNOWN_BODY)
+			if err != nil {
+				return err
+			}
+			p.world.AddBehavior(behavior)
+
+		case OP_REMOVE_BEHAVIOR:
+			var i uint32
+			if err := p.get(&i); err != nil {
+				return err
+			}
+			list := p.world.Behaviors()
+			if int(i) >= len(list) {
+				return ERROR_UNKNOWN_BEHAVIOR
+			}
+			p.world.RemoveBehavior(list[i])
+
+		default:
+			return ERROR_REPLAY_FORMAT
+		}
+	}
+}
+
+// Play runs the replay to the end
+func (p *Player) Play() error {
+	for {
+		switch err := p.Next(); err {
+		case nil:
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+func (p *Player) body() (bodies.Body, error) {
+	var n uint32
+	if err := p.get(&n); err != nil {
+		return nil, err
+	}
+	if int(n) >= len(p.bodies) {
+		return nil, ERROR_UNKNOWN_BODY
+	}
+	return p.bodies[n], nil
+}
+
+func (p *Player) get(values ...interface{}) error {
+	for _, v := range values {
+		if err := binary.Read(p.in, binary.LittleEndian, v); err != nil {
+			return unexpected(err)
+		}
+	}
+	return nil
+}
+
+func (p *Player) getBytes() ([]byte, error) {
+	var size uint32
+	if err := p.get(&size); err != nil {
+		return nil, err
+	}
+	if size > math.MaxInt32 {
+		return nil, ERROR_REPLAY_FORMAT
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(p.in, data); err != nil {
+		return nil, unexpected(err)
+	}
+	return data, nil
+}
+
+func (p *Player) getJSON(v interface{}) error {
+	data, err := p.getBytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// a record cut in half is not the end of the replay
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package physics
+
+import (
+	"bytes"
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"testing"
+)
+
+func Test_Replay(t *testing.T) {
+	Convey("Replay", t, func() {
+		w := NewWorldLockstep()
+		w.Add(
+			behaviors.NewSweepPrune(),
+			behaviors.NewBodyCollisionDetection(),
+			behaviors.NewBodyImpulseResponse(),
+		)
+		ground := bodies.NewRectangle(200, 10)
+		ground.SetPosition(0, 40)
+		ground.SetTreatment(bodies.TREATMENT_STATIC)
+		w.Add(ground)
+		for i := 0; i < 3; i++ {
+			box := bodies.NewRectangle(4, 4)
+			box.SetPosition(float64(i)*3.5, 20-float64(i)*5)
+			w.Add(box)
+		}
+		for i := 0; i < 20; i++ {
+			w.StepTick()
+		}
+
+		file := &bytes.Buffer{}
+		r, err := NewRecorder(w, file)
+		So(err, ShouldBeNil)
+
+		ball := bodies.NewCircle(2)
+		for tick := 0; tick < 150; tick++ {
+			switch tick {
+			case 10:
+				So(r.AddBehavior(behaviors.NewConstantAcceleration(0, 0.0004)), ShouldBeNil)
+			case 30:
+				So(r.AddBody(ball), ShouldBeNil)
+				So(r.SetVelocity(ball, 0.01, 0), ShouldBeNil)
+			case 60:
+				So(r.ApplyForce(w.Bodies()[1], geom.Vector{0.002, -0.01}, geom.Vector{1, 1}), ShouldBeNil)
+			case 90:
+				So(r.SetPosition(w.Bodies()[2], -20, 10), ShouldBeNil)
+			case 120:
+				So(r.RemoveBody(ball), ShouldBeNil)
+			}
+			So(r.Step(), ShouldBeNil)
+		}
+		So(r.RemoveBody(ball), ShouldEqual, ERROR_UNKNOWN_BODY)
+
+		Convey("should play the run again", func() {
+			p, err := NewPlayer(bytes.NewReader(file.Bytes()))
+			So(err, ShouldBeNil)
+			So(p.World().Tick(), ShouldEqual, 20)
+			So(p.Play(), ShouldBeNil)
+			So(p.World().Tick(), ShouldEqual, w.Tick())
+			So(p.World().Checksum(), ShouldEqual, w.Checksum())
+			So(p.World().Bodies(), ShouldHaveLength, 4)
+		})
+
+		Convey("should find desyncs", func() {
+			p, err := NewPlayer(bytes.NewReader(file.Bytes()))
+			So(err, ShouldBeNil)
+			for i := 0; i < 5; i++ {
+				So(p.Next(), ShouldBeNil)
+			}
+			p.World().Bodies()[1].State().Pos.X += 1e-9
+			err = p.Next()
+			So(err, ShouldHaveSameTypeAs, &DesyncError{})
+			So(err.(*DesyncError).Tick, ShouldEqual, 26)
+		})
+
+		Convey("should reject other files", func() {
+			_, err := NewPlayer(bytes.NewReader([]byte("nope")))
+			So(err, ShouldEqual, ERROR_REPLAY_FORMAT)
+
+			p, err := NewPlayer(bytes.NewReader(file.Bytes()[:file.Len()-3]))
+			So(err, ShouldBeNil)
+			So(p.Play(), ShouldEqual, io.ErrUnexpectedEOF)
+		})
+
+		Convey("should play a world of the recorded kind", func() {
+			w := NewWorldImprovedEuler()
+			gravity := behaviors.NewConstantAcceleration(0, 0.0004)
+			w.Add(gravity, bodies.NewCircle(1))
+
+			file := &bytes.Buffer{}
+			r, err := NewRecorder(w, file)
+			So(err, ShouldBeNil)
+			for tick := 0; tick < 40; tick++ {
+				if tick == 20 {
+					// the new strength is recorded with the replacement
+					So(r.ReplaceBehavior(gravity, behaviors.NewConstantAcceleration(0.0001, -0.0002)), ShouldBeNil)
+				}
+				So(r.Step(), ShouldBeNil)
+			}
+			So(r.ReplaceBehavior(gravity, gravity), ShouldEqual, ERROR_UNKNOWN_BEHAVIOR)
+
+			p, err := NewPlayer(bytes.NewReader(file.Bytes()))
+			So(err, ShouldBeNil)
+			So(p.World().(*world).kind, ShouldEqual, "improved-euler")
+			So(p.Play(), ShouldBeNil)
+			So(p.World().Checksum(), ShouldEqual, w.Checksum())
+		})
+	})
+}
@@ -0,0 +1,341 @@
+package physics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/oniproject/physics.go/geom"
+	"math"
+	"sort"
+)
+
+var ERROR_NO_BASELINE = errors.New("Error: The baseline of the state is not known.")
+var ERROR_STATE_FORMAT = errors.New("Error: Broken state data.")
+
+// Precision is the step each value is rounded to
+type Precision struct {
+	Pos, Vel, Angle, AngularVel float64
+}
+
+var DefaultPrecision = Precision{Pos: 1.0 / 256, Vel: 1.0 / 4096, Angle: 1.0 / 4096, AngularVel: 1.0 / 65536}
+
+// BodyFrame is the quantized state of a body
+type BodyFrame struct {
+	Pos, Vel          [2]int64
+	Angle, AngularVel int64
+	Asleep            bool
+}
+
+// StateFrame holds the bodies of a world at a tick, by UID.
+// The server and the clients must give the bodies the same UIDs,
+// lockstep worlds built the same way do.
+// Frame numbers the frames for the baselines, StateServer.Capture sets it.
+// The tick can't be used for it: a world stepped by time may be captured
+// twice at the same tick, or with bodies changed between the steps
+type StateFrame struct {
+	Frame  uint64
+	Tick   uint64
+	Bodies map[int64]BodyFrame
+}
+
+func quantize(v, step float64) int64 { return int64(math.Floor(v/step + 0.5)) }
+
+// Capture quantizes the bodies of the world
+func (p Precision) Capture(w World) *StateFrame {
+	frame := &StateFrame{Tick: w.Tick(), Bodies: map[int64]BodyFrame{}}
+	for _, body := range w.Bodies() {
+		state := body.State()
+		frame.Bodies[body.UID()] = BodyFrame{
+			Pos:        [2]int64{quantize(state.Pos.X, p.Pos), quantize(state.Pos.Y, p.Pos)},
+			Vel:        [2]int64{quantize(state.Vel.X, p.Vel), quantize(state.Vel.Y, p.Vel)},
+			Angle:      quantize(state.Angular.Pos, p.Angle),
+			AngularVel: quantize(state.Angular.Vel, p.AngularVel),
+			Asleep:     body.Asleep(),
+		}
+	}
+	return frame
+}
+
+// Apply puts the states into the bodies of the world with the same UIDs.
+// Bodies the frame doesn't know are left alone
+func (p Precision) Apply(frame *StateFrame, w World) {
+	for _, body := range w.Bodies() {
+		b, ok := frame.Bodies[body.UID()]
+		if !ok {
+			continue
+		}
+		state := body.State()
+		state.Pos = geom.Vector{float64(b.Pos[0]) * p.Pos, float64(b.Pos[1]) * p.Pos}
+		state.Vel = geom.Vector{float64(b.Vel[0]) * p.Vel, float64(b.Vel[1]) * p.Vel}
+		state.Angular.Pos = float64(b.Angle) * p.Angle
+		state.Angular.Vel = float64(b.AngularVel) * p.AngularVel
+		if b.Asleep {
+			body.Sleep()
+		} else {
+			body.Wake()
+		}
+	}
+}
+
+// the bits of the mask in front of every body
+const (
+	deltaPos = 1 << iota
+	deltaVel
+	deltaAngle
+	deltaAngularVel
+	deltaAsleep // the value, not a change
+	deltaRemoved
+)
+
+type varintBuffer struct {
+	bytes.Buffer
+	tmp [binary.MaxVarintLen64]byte
+}
+
+func (b *varintBuffer) uvarint(v uint64) { b.Write(b.tmp[:binary.PutUvarint(b.tmp[:], v)]) }
+func (b *varintBuffer) varint(v int64)   { b.Write(b.tmp[:binary.PutVarint(b.tmp[:], v)]) }
+
+// EncodeState writes the bodies that differ from the baseline,
+// or all of them if it is nil. The values are written as differences
+// to the baseline in zigzag varints
+func EncodeState(frame, baseline *StateFrame) []byte {
+	out := &varintBuffer{}
+	out.uvarint(frame.Frame)
+	out.uvarint(frame.Tick)
+	if baseline == nil {
+		out.WriteByte(0)
+		baseline = &StateFrame{}
+	} else {
+		out.WriteByte(1)
+		out.uvarint(baseline.Frame)
+	}
+
+	// the UIDs of both frames in order
+	uids := []int64{}
+	for uid := range frame.Bodies {
+		uids = append(uids, uid)
+	}
+	for uid := range baseline.Bodies {
+		if _, ok := frame.Bodies[uid]; !ok {
+			uids = append(uids, uid)
+		}
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+
+	entries := &varintBuffer{}
+	count := uint64(0)
+	last := int64(0)
+	for _, uid := range uids {
+		b, now := frame.Bodies[uid]
+		old, before := baseline.Bodies[uid]
+
+		mask := byte(0)
+		switch {
+		case !now:
+			mask = deltaRemoved
+		case !before:
+			mask = deltaPos | deltaVel | deltaAngle | deltaAngularVel
+		default:
+			if b.Pos != old.Pos {
+				mask |= deltaPos
+			}
+			if b.Vel != old.Vel {
+				mask |= deltaVel
+			}
+			if b.Angle != old.Angle {
+				mask |= deltaAngle
+			}
+			if b.AngularVel != old.AngularVel {
+				mask |= deltaAngularVel
+			}
+			if mask == 0 && b.Asleep == old.Asleep {
+				continue
+			}
+		}
+		if now && b.Asleep {
+			mask |= deltaAsleep
+		}
+
+		count++
+		entries.varint(uid - last)
+		last = uid
+		entries.WriteByte(mask)
+		if mask&deltaPos != 0 {
+			entries.varint(b.Pos[0] - old.Pos[0])
+			entries.varint(b.Pos[1] - old.Pos[1])
+		}
+		if mask&deltaVel != 0 {
+			entries.varint(b.Vel[0] - old.Vel[0])
+			entries.varint(b.Vel[1] - old.Vel[1])
+		}
+		if mask&deltaAngle != 0 {
+			entries.varint(b.Angle - old.Angle)
+		}
+		if mask&deltaAngularVel != 0 {
+			entries.varint(b.AngularVel - old.AngularVel)
+		}
+	}
+
+	out.uvarint(count)
+	out.Write(entries.Bytes())
+	return out.Bytes()
+}
+
+// DecodeState reads data written by EncodeState. baseline looks up the
+// frame the data was encoded against by its number, it may be nil for full states
+func DecodeState(data []byte, baseline func(frame uint64) *StateFrame) (frame *StateFrame, err error) {
+	r := bytes.NewReader(data)
+	getU := func() uint64 {
+		v, e := binary.ReadUvarint(r)
+		if e != nil && err == nil {
+			err = ERROR_STATE_FORMAT
+		}
+		return v
+	}
+	getI := func() int64 {
+		v, e := binary.ReadVarint(r)
+		if e != nil && err == nil {
+			err = ERROR_STATE_FORMAT
+		}
+		return v
+	}
+
+	frame = &StateFrame{Frame: getU(), Tick: getU(), Bodies: map[int64]BodyFrame{}}
+	base := &StateFrame{}
+	if flag, e := r.ReadByte(); e != nil {
+		return nil, ERROR_STATE_FORMAT
+	} else if flag == 1 {
+		n := getU()
+		if err != nil {
+			return nil, err
+		}
+		if baseline != nil {
+			base = baseline(n)
+		}
+		if base == nil || base.Frame != n {
+			return nil, ERROR_NO_BASELINE
+		}
+		for uid, b := range base.Bodies {
+			frame.Bodies[uid] = b
+		}
+	}
+
+	count := getU()
+	uid := int64(0)
+	for i := uint64(0); i < count && err == nil; i++ {
+		uid += getI()
+		mask, e := r.ReadByte()
+		if e != nil {
+			return nil, ERROR_STATE_FORMAT
+		}
+		if mask&deltaRemoved != 0 {
+			delete(frame.Bodies, uid)
+			continue
+		}
+
+		b := frame.Bodies[uid]
+		if mask&deltaPos != 0 {
+			b.Pos[0] += getI()
+			b.Pos[1] += getI()
+		}
+		if mask&deltaVel != 0 {
+			b.Vel[0] += getI()
+			b.Vel[1] += getI()
+		}
+		if mask&deltaAngle != 0 {
+			b.Angle += getI()
+		}
+		if mask&deltaAngularVel != 0 {
+			b.AngularVel += getI()
+		}
+		b.Asleep = mask&deltaAsleep != 0
+		frame.Bodies[uid] = b
+	}
+	if err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// frameRing keeps the last frames by number
+type frameRing []*StateFrame
+
+func (r frameRing) put(frame *StateFrame) { r[frame.Frame%uint64(len(r))] = frame }
+
+func (r frameRing) get(n uint64) *StateFrame {
+	frame := r[n%uint64(len(r))]
+	if frame == nil || frame.Frame != n {
+		return nil
+	}
+	return frame
+}
+
+// StateServer encodes the frames for every client against
+// the last frame the client acknowledged
+type StateServer struct {
+	Precision Precision
+	frames    frameRing
+	next      uint64
+	acked     map[interface{}]uint64
+}
+
+// NewStateServer keeps history frames. Clients that acknowledged
+// an older one get the full state
+func NewStateServer(precision Precision, history int) *StateServer {
+	return &StateServer{
+		Precision: precision,
+		frames:    make(frameRing, history),
+		acked:     map[interface{}]uint64{},
+	}
+}
+
+// Capture quantizes the world, numbers the frame and keeps it as a baseline
+func (s *StateServer) Capture(w World) *StateFrame {
+	frame := s.Precision.Capture(w)
+	s.next++
+	frame.Frame = s.next
+	s.frames.put(frame)
+	return frame
+}
+
+// Ack marks the frame as received by the client
+func (s *StateServer) Ack(client interface{}, frame uint64) {
+	if last, ok := s.acked[client]; !ok || frame > last {
+		s.acked[client] = frame
+	}
+}
+
+func (s *StateServer) Forget(client interface{}) { delete(s.acked, client) }
+
+func (s *StateServer) Encode(client interface{}, frame *StateFrame) []byte {
+	var baseline *StateFrame
+	if n, ok := s.acked[client]; ok {
+		baseline = s.frames.get(n)
+	}
+	return EncodeState(frame, baseline)
+}
+
+// StateClient decodes the frames of a StateServer
+type StateClient struct {
+	Precision Precision
+	frames    frameRing
+}
+
+// NewStateClient keeps history frames, it should be
+// at least as many as the server keeps
+func NewStateClient(precision Precision, history int) *StateClient {
+	return &StateClient{Precision: precision, frames: make(frameRing, history)}
+}
+
+// Decode returns the frame and keeps it as a baseline,
+// its number should be acknowledged to the server
+func (c *StateClient) Decode(data []byte) (*StateFrame, error) {
+	frame, err := DecodeState(data, c.frames.get)
+	if err != nil {
+		return nil, err
+	}
+	c.frames.put(frame)
+	return frame, nil
+}
+
+func (c *StateClient) Apply(frame *StateFrame, w World) { c.Precision.Apply(frame, w) }
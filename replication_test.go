@@ -0,0 +1,113 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func Test_Replication(t *testing.T) {
+	Convey("Replication", t, func() {
+		newWorld := func() World {
+			w := NewWorldLockstep()
+			w.Add(behaviors.NewConstantAcceleration(0, 0.0004))
+			for i := 0; i < 10; i++ {
+				body := bodies.NewCircle(1)
+				body.SetPosition(float64(i)*5, 0)
+				if i > 0 {
+					// only the first one moves
+					body.SetTreatment(bodies.TREATMENT_STATIC)
+				}
+				w.Add(body)
+			}
+			return w
+		}
+
+		server, client := newWorld(), newWorld()
+		out := NewStateServer(DefaultPrecision, 32)
+		in := NewStateClient(DefaultPrecision, 32)
+		for i := 0; i < 5; i++ {
+			server.StepTick()
+		}
+
+		full := out.Encode("bob", out.Capture(server))
+		frame, err := in.Decode(full)
+		So(err, ShouldBeNil)
+		in.Apply(frame, client)
+		out.Ack("bob", frame.Frame)
+
+		moving := server.Bodies()[0].State()
+		So(client.Bodies()[0].State().Pos.Y, ShouldAlmostEqual, moving.Pos.Y, DefaultPrecision.Pos)
+		So(client.Bodies()[0].State().Vel.Y, ShouldAlmostEqual, moving.Vel.Y, DefaultPrecision.Vel)
+		So(client.Bodies()[5].State().Pos.X, ShouldEqual, 25)
+
+		Convey("should only send what changed since the acknowledged frame", func() {
+			server.StepTick()
+			delta := out.Encode("bob", out.Capture(server))
+			So(len(delta), ShouldBeLessThan, len(full)/4)
+
+			frame, err := in.Decode(delta)
+			So(err, ShouldBeNil)
+			So(frame.Bodies, ShouldHaveLength, 10)
+			in.Apply(frame, client)
+			So(client.Bodies()[0].State().Pos.Y, ShouldAlmostEqual, moving.Pos.Y, DefaultPrecision.Pos)
+
+			// a new client gets everything
+			So(len(out.Encode("alice", frame)), ShouldBeGreaterThan, len(delta))
+		})
+
+		Convey("should send sleeping and removed bodies", func() {
+			server.Bodies()[0].Sleep()
+			server.Remove(server.Bodies()[9])
+			server.StepTick()
+
+			frame, err := in.Decode(out.Encode("bob", out.Capture(server)))
+			So(err, ShouldBeNil)
+			So(frame.Bodies, ShouldHaveLength, 9)
+			So(frame.Bodies[server.Bodies()[0].UID()].Asleep, ShouldBeTrue)
+		})
+
+		Convey("should need the baseline", func() {
+			server.StepTick()
+			delta := out.Encode("bob", out.Capture(server))
+			_, err := NewStateClient(DefaultPrecision, 32).Decode(delta)
+			So(err, ShouldEqual, ERROR_NO_BASELINE)
+			_, err = in.Decode(delta[:len(delta)-1])
+			So(err, ShouldEqual, ERROR_STATE_FORMAT)
+		})
+
+		Convey("should number the frames of a world stepped by time", func() {
+			server := NewWorldImprovedEuler()
+			server.Add(behaviors.NewConstantAcceleration(0, 0.0004))
+			ball, wall := bodies.NewCircle(1), bodies.NewCircle(1)
+			wall.SetPosition(10, 0)
+			server.Add(ball, wall)
+			out := NewStateServer(DefaultPrecision, 32)
+			in := NewStateClient(DefaultPrecision, 32)
+
+			now := time.Unix(0, 0)
+			server.Step(now)
+			first, err := in.Decode(out.Encode("bob", out.Capture(server)))
+			So(err, ShouldBeNil)
+			out.Ack("bob", first.Frame)
+
+			// a second frame at the same tick, with a body gone,
+			// that never reaches the client
+			server.Remove(wall)
+			lost := out.Capture(server)
+			So(lost.Tick, ShouldEqual, first.Tick)
+			So(lost.Frame, ShouldNotEqual, first.Frame)
+
+			// less than a timestep, the tick stays
+			now = now.Add(server.TimeStep() / 2)
+			server.Step(now)
+			So(server.Tick(), ShouldEqual, first.Tick)
+			frame := out.Capture(server)
+			got, err := in.Decode(out.Encode("bob", frame))
+			So(err, ShouldBeNil)
+			So(got.Bodies, ShouldResemble, frame.Bodies)
+		})
+	})
+}
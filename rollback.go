@@ -0,0 +1,140 @@
+package physics
+
+import (
+	"errors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+)
+
+var ERROR_TICK_OUT_OF_RANGE = errors.New("Error: The tick is not in the rollback buffer.")
+
+// Input changes the world at the start of a tick
+type Input func(w World)
+
+// Correction is how far a body was from where it should have been,
+// the predicted position minus the corrected one. Renderers can draw
+// the body at Pos + Correction.Pos and shrink the offset over a few frames
+type Correction struct {
+	Body  bodies.Body
+	Pos   geom.Vector
+	Angle float64
+}
+
+// Rollback keeps the last ticks of a world with their inputs,
+// so they can be played again when the inputs turn out to be wrong.
+// The world must only be stepped through Step
+type Rollback struct {
+	world World
+	ring  []rollbackTick
+	first uint64 // the oldest tick in the ring
+	count int
+}
+
+type rollbackTick struct {
+	snapshot *Snapshot // before the input
+	input    Input
+}
+
+func NewRollback(w World, size int) *Rollback {
+	return &Rollback{
+		world: w,
+		ring:  make([]rollbackTick, size),
+		first: w.Tick(),
+	}
+}
+
+func (r *Rollback) World() World { return r.world }
+
+// Oldest returns the first tick that can be rewound to
+func (r *Rollback) Oldest() uint64 { return r.first }
+
+// Step saves the world, applies the input (it can be nil) and steps one tick
+func (r *Rollback) Step(input Input) {
+	tick := r.world.Tick()
+	if r.count == len(r.ring) {
+		r.first++
+		r.count--
+	}
+	r.count++
+	r.ring[tick%uint64(len(r.ring))] = rollbackTick{r.world.Snapshot(), input}
+	r.run(tick)
+}
+
+func (r *Rollback) run(tick uint64) {
+	if input := r.ring[tick%uint64(len(r.ring))].input; input != nil {
+		input(r.world)
+	}
+	r.world.StepTick()
+}
+
+func (r *Rollback) inRange(tick uint64) bool {
+	return tick >= r.first && tick < r.first+uint64(r.count)
+}
+
+// Rewind puts the world back to the start of the tick
+// and forgets the ticks after it
+func (r *Rollback) Rewind(tick uint64) error {
+	if !r.inRange(tick) {
+		return ERROR_TICK_OUT_OF_RANGE
+	}
+	r.world.Restore(r.ring[tick%uint64(len(r.ring))].snapshot)
+	r.count = int(tick - r.first)
+	return nil
+}
+
+// Correct replaces the inputs of some ticks, goes back to the first
+// of them and simulates up to the present again. It returns the
+// corrections of the bodies that were in the world before and after
+func (r *Rollback) Correct(inputs map[uint64]Input) ([]Correction, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	from := r.world.Tick()
+	for tick := range inputs {
+		if !r.inRange(tick) {
+			return nil, ERROR_TICK_OUT_OF_RANGE
+		}
+		if tick < from {
+			from = tick
+		}
+	}
+
+	type predicted struct {
+		pos   geom.Vector
+		angle float64
+	}
+	before := map[bodies.Body]predicted{}
+	for _, body := range r.world.Bodies() {
+		before[body] = predicted{body.State().Pos, body.State().Angular.Pos}
+	}
+
+	present := r.world.Tick()
+	size := uint64(len(r.ring))
+	for tick, input := range inputs {
+		r.ring[tick%size].input = input
+	}
+
+	r.world.Restore(r.ring[from%size].snapshot)
+	for tick := from; tick < present; tick++ {
+		if tick != from {
+			r.ring[tick%size].snapshot = r.world.Snapshot()
+		}
+		r.run(tick)
+	}
+
+	corrections := []Correction{}
+	for _, body := range r.world.Bodies() {
+		p, ok := before[body]
+		if !ok {
+			continue
+		}
+		state := body.State()
+		corrections = append(corrections, Correction{
+			Body:  body,
+			Pos:   p.pos.Minus(state.Pos),
+			Angle: p.angle - state.Angular.Pos,
+		})
+	}
+	return corrections, nil
+}
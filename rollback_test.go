@@ -0,0 +1,76 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Rollback(t *testing.T) {
+	Convey("Rollback", t, func() {
+		newWorld := func() World {
+			w := NewWorldLockstep()
+			w.Add(
+				behaviors.NewSweepPrune(),
+				behaviors.NewBodyCollisionDetection(),
+				behaviors.NewBodyImpulseResponse(),
+			)
+			a, b := bodies.NewCircle(2), bodies.NewCircle(2)
+			b.SetPosition(10, 0.5)
+			w.Add(a, b)
+			return w
+		}
+		push := func(w World) { w.Bodies()[0].SetVelocity(0.005, 0) }
+
+		// the server knows the body was pushed on tick 5
+		server := newWorld()
+		for tick := 0; tick < 30; tick++ {
+			if tick == 5 {
+				push(server)
+			}
+			server.StepTick()
+		}
+
+		// the client didn't
+		client := NewRollback(newWorld(), 64)
+		for tick := 0; tick < 30; tick++ {
+			client.Step(nil)
+		}
+		So(client.World().Checksum(), ShouldNotEqual, server.Checksum())
+
+		Convey("should simulate again with the corrected input", func() {
+			corrections, err := client.Correct(map[uint64]Input{5: push})
+			So(err, ShouldBeNil)
+			So(client.World().Tick(), ShouldEqual, 30)
+			So(client.World().Checksum(), ShouldEqual, server.Checksum())
+
+			So(corrections, ShouldHaveLength, 2)
+			So(corrections[0].Body, ShouldEqual, client.World().Bodies()[0])
+			So(corrections[0].Pos.X, ShouldBeLessThan, 0)
+
+			// correcting with the same input changes nothing
+			corrections, err = client.Correct(map[uint64]Input{5: push})
+			So(err, ShouldBeNil)
+			So(corrections[0].Pos.X, ShouldEqual, 0)
+		})
+
+		Convey("should rewind", func() {
+			So(client.Rewind(10), ShouldBeNil)
+			So(client.World().Tick(), ShouldEqual, 10)
+			So(client.Rewind(20), ShouldEqual, ERROR_TICK_OUT_OF_RANGE)
+		})
+
+		Convey("should forget old ticks", func() {
+			small := NewRollback(newWorld(), 8)
+			for tick := 0; tick < 20; tick++ {
+				small.Step(nil)
+			}
+			So(small.Oldest(), ShouldEqual, 12)
+			_, err := small.Correct(map[uint64]Input{11: push})
+			So(err, ShouldEqual, ERROR_TICK_OUT_OF_RANGE)
+			_, err = small.Correct(map[uint64]Input{12: push})
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,251 @@
+package physics
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/integrators"
+	"github.com/oniproject/physics.go/util"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// the version SaveScene writes. LoadScene reads this one and older ones
+const SCENE_VERSION = 1
+
+var ERROR_SCENE_VERSION = errors.New("Error: Unsupported scene version.")
+var ERROR_UNKNOWN_BEHAVIOR = errors.New("Error: Unknown behavior type.")
+var ERROR_UNKNOWN_INTEGRATOR = errors.New("Error: Unknown integrator type.")
+var ERROR_BAD_TARGET = errors.New("Error: Behavior target is not a body of the scene.")
+
+// Scene is the JSON form of a world
+type Scene struct {
+	Version    int               `json:"version"`
+	TimeStep   time.Duration     `json:"timestep,omitempty"` // in nanoseconds
+	Sleeping   *SleepOptions     `json:"sleeping,omitempty"`
+	Integrator *IntegratorData   `json:"integrator,omitempty"`
+	Bodies     []bodies.BodyData `json:"bodies"`
+	Behaviors  []BehaviorData    `json:"behaviors"`
+}
+
+// IntegratorData is the JSON form of a registered integrator
+type IntegratorData = util.ComponentData
+
+// BehaviorData is the JSON form of a registered behavior: the fields
+// of its options, the missing ones keep their defaults. "targets" has
+// the indexes of the bodies given to ApplyTo, it is left out for
+// behaviors that act on all the bodies
+type BehaviorData = util.ComponentData
+
+// SceneFloat writes infinities as "Infinity" and "-Infinity",
+// which JSON numbers can't hold
+type SceneFloat float64
+
+func (f SceneFloat) MarshalJSON() ([]byte, error) {
+	switch {
+	case math.IsInf(float64(f), 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(float64(f), -1):
+		return []byte(`"-Infinity"`), nil
+	}
+	return []byte(strconv.FormatFloat(float64(f), 'g', -1, 64)), nil
+}
+
+func (f *SceneFloat) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"Infinity"`:
+		*f = SceneFloat(math.Inf(1))
+		return nil
+	case `"-Infinity"`:
+		*f = SceneFloat(math.Inf(-1))
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	*f = SceneFloat(v)
+	return err
+}
+
+// SaveScene writes the world as JSON. The clock is not saved
+func SaveScene(w World, out io.Writer) error {
+	scene, err := encodeScene(w)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "\t")
+	return enc.Encode(scene)
+}
+
+// LoadScene makes a new world from JSON written by SaveScene or by hand
+func LoadScene(in io.Reader) (World, error) {
+	scene := &Scene{}
+	if err := json.NewDecoder(in).Decode(scene); err != nil {
+		return nil, err
+	}
+	w := NewWorldImprovedEuler()
+	if err := decodeScene(scene, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func encodeScene(w World) (*Scene, error) {
+	scene := &Scene{
+		Version:  SCENE_VERSION,
+		TimeStep: w.TimeStep(),
+	}
+	if sleeping := w.Sleeping(); sleeping.Ticks != 0 {
+		scene.Sleeping = &sleeping
+	}
+
+	integrator, err := integratorRegistry.Encode(w.Integrator())
+	if err == util.ERROR_UNDESCRIBED {
+		err = ERROR_UNKNOWN_INTEGRATOR
+	}
+	if err != nil {
+		return nil, err
+	}
+	scene.Integrator = &integrator
+
+	index := map[bodies.Body]int{}
+	for i, body := range w.Bodies() {
+		d, err := bodies.EncodeBody(body)
+		if err != nil {
+			return nil, err
+		}
+		index[body] = i
+		scene.Bodies = append(scene.Bodies, d)
+	}
+
+	for _, behavior := range w.Behaviors() {
+		d, err := encodeBehavior(behavior, func(body bodies.Body) (int, bool) {
+			i, ok := index[body]
+			return i, ok
+		}, ERROR_BAD_TARGET)
+		if err != nil {
+			return nil, err
+		}
+		scene.Behaviors = append(scene.Behaviors, d)
+	}
+
+	return scene, nil
+}
+
+// ownTargets returns the bodies given to ApplyTo,
+// ok is false for behaviors that act on all the bodies
+func ownTargets(behavior behaviors.Behavior) (targets []bodies.Body, ok bool) {
+	if all, is := behavior.(behaviors.AllBodies); is && all.AllBodies() {
+		return nil, false
+	}
+	targets = behavior.Targets()
+	return targets, targets != nil
+}
+
+// encodeBehavior numbers the targets with index,
+// bad is returned for the ones it doesn't know
+func encodeBehavior(behavior behaviors.Behavior, index func(bodies.Body) (int, bool), bad error) (d BehaviorData, err error) {
+	d, err = behaviorRegistry.Encode(behavior)
+	if err == util.ERROR_UNDESCRIBED {
+		err = ERROR_UNKNOWN_BEHAVIOR
+	}
+	if err != nil {
+		return
+	}
+
+	if targets, ok := ownTargets(behavior); ok {
+		list := []int{}
+		for _, target := range targets {
+			i, ok := index(target)
+			if !ok {
+				return d, bad
+			}
+			list = append(list, i)
+		}
+		data, err := json.Marshal(list)
+		if err != nil {
+			return d, err
+		}
+		d.Fields["targets"] = data
+	}
+	return
+}
+
+// decodeBehavior finds the targets with body, bad is returned
+// for the numbers it doesn't know
+func decodeBehavior(d BehaviorData, body func(int) (bodies.Body, bool), bad error) (behaviors.Behavior, error) {
+	b, err := behaviorRegistry.Decode(d)
+	if err != nil {
+		return nil, err
+	}
+	behavior := b.(behaviors.Behavior)
+
+	if data, ok := d.Fields["targets"]; ok {
+		list := []int{}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+		targets := []bodies.Body{}
+		for _, i := range list {
+			target, ok := body(i)
+			if !ok {
+				return nil, bad
+			}
+			targets = append(targets, target)
+		}
+		behavior.ApplyTo(targets)
+	}
+	return behavior, nil
+}
+
+func decodeScene(scene *Scene, w World) error {
+	if scene.Version < 1 || scene.Version > SCENE_VERSION {
+		return ERROR_SCENE_VERSION
+	}
+
+	w.SetTimeStep(scene.TimeStep)
+	sleeping := SleepOptions{}
+	if scene.Sleeping != nil {
+		sleeping = *scene.Sleeping
+	}
+	w.SetSleeping(sleeping)
+
+	if scene.Integrator != nil {
+		integrator, err := integratorRegistry.Decode(*scene.Integrator)
+		if err != nil {
+			return err
+		}
+		w.SetIntegrator(integrator.(integrators.Integrator))
+	}
+
+	list := []bodies.Body{}
+	for _, d := range scene.Bodies {
+		body, err := bodies.DecodeBody(d)
+		if err != nil {
+			return err
+		}
+		list = append(list, body)
+	}
+
+	// behaviors go first, some of them track the bodies as they are added
+	for _, d := range scene.Behaviors {
+		behavior, err := decodeBehavior(d, func(i int) (bodies.Body, bool) {
+			if i < 0 || i >= len(list) {
+				return nil, false
+			}
+			return list[i], true
+		}, ERROR_BAD_TARGET)
+		if err != nil {
+			return err
+		}
+		w.AddBehavior(behavior)
+	}
+
+	for _, body := range list {
+		w.AddBody(body)
+	}
+
+	return nil
+}
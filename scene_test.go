@@ -0,0 +1,170 @@
+package physics
+
+import (
+	"bytes"
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	"github.com/oniproject/physics.go/integrators"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Scene(t *testing.T) {
+	Convey("Scene", t, func() {
+		Convey("should round-trip a world", func() {
+			world := NewWorldImprovedEuler()
+			world.SetTimeStep(time.Second / 60)
+			world.SetSleeping(SleepOptions{Vel: 0.01, AngularVel: 0.001, Ticks: 60})
+			world.Integrator().(*integrators.ImprovedEuler).Drag = 0.01
+
+			box := bodies.NewRectangle(4, 2)
+			box.SetPosition(1, 2)
+			box.SetVelocity(0.1, 0)
+			box.SetDensity(3)
+			box.SetScale(1.5)
+			poly := bodies.NewConvexPolygon([]geom.Vector{{0, 0}, {3, 0}, {1, 2}})
+			poly.SetTreatment(bodies.TREATMENT_KINEMATIC)
+			poly.Sleep()
+			compound := bodies.NewCompound([]geometries.Child{
+				{Geometry: geometries.NewCircle(1)},
+				{Geometry: geometries.NewCapsule(1, 0.5), Pos: geom.Vector{3, 0}, Angle: 0.3},
+			})
+			ground := bodies.NewHeightfield([]float64{1, 2, 0.5}, 10, 2)
+			chain := bodies.NewChain([]geom.Vector{{0, 0}, {5, 1}, {9, 0}}, false)
+			chain.SetHidden(true)
+
+			gravity := behaviors.NewConstantAcceleration(0, 0.001)
+			gravity.ApplyTo([]bodies.Body{box, compound})
+			// an empty list is not all the bodies
+			newtonian := behaviors.NewNewtonian(2)
+			newtonian.ApplyTo([]bodies.Body{})
+			world.Add(
+				gravity,
+				newtonian,
+				behaviors.NewAttractor(),
+				behaviors.NewEdgeCollisionDetection(geom.AABB{X: 50, Y: 50, HW: 50, HH: 40}, 0.5, 0.7),
+				behaviors.NewSweepPrune(),
+				behaviors.NewBodyCollisionDetection(),
+				behaviors.NewBodyImpulseResponse(),
+				box, poly, compound, ground, chain,
+			)
+
+			now := time.Unix(0, 0)
+			for i := 0; i < 10; i++ {
+				now = now.Add(world.TimeStep())
+				world.Step(now)
+			}
+
+			first := &bytes.Buffer{}
+			So(SaveScene(world, first), ShouldBeNil)
+
+			loaded, err := LoadScene(bytes.NewReader(first.Bytes()))
+			So(err, ShouldBeNil)
+			second := &bytes.Buffer{}
+			So(SaveScene(loaded, second), ShouldBeNil)
+			So(second.String(), ShouldEqual, first.String())
+
+			So(loaded.TimeStep(), ShouldEqual, time.Second/60)
+			So(loaded.Sleeping(), ShouldResemble, world.Sleeping())
+			So(loaded.Bodies(), ShouldHaveLength, 5)
+			for i, body := range world.Bodies() {
+				So(loaded.Bodies()[i].Snapshot(), ShouldResemble, body.Snapshot())
+			}
+			So(loaded.Behaviors()[0].Targets(), ShouldHaveLength, 2)
+			So(loaded.Behaviors()[1].Targets(), ShouldBeEmpty)
+			So(loaded.Behaviors()[1].(behaviors.AllBodies).AllBodies(), ShouldBeFalse)
+		})
+
+		Convey("should fill in the defaults of hand written scenes", func() {
+			world, err := LoadScene(strings.NewReader(`{
+				"version": 1,
+				"bodies": [
+					{"type": "circle", "geometry": {"type": "circle", "radius": 2}, "state": {"pos": {"x": 5, "y": 1}}, "mass": 4},
+					{"type": "segment", "geometry": {"type": "segment", "a": {"x": 0, "y": 0}, "b": {"x": 10, "y": 0}}}
+				],
+				"behaviors": [
+					{"type": "constant-acceleration"},
+					{"type": "attractor", "max": "Infinity", "strength": 3}
+				]
+			}`))
+			So(err, ShouldBeNil)
+
+			circle, segment := world.Bodies()[0], world.Bodies()[1]
+			So(circle.State().Pos, ShouldResemble, geom.Vector{5, 1})
+			So(circle.Mass(), ShouldEqual, 4)
+			So(circle.MOI(), ShouldEqual, 8)
+			So(circle.Cof(), ShouldEqual, 0.8)
+			So(circle.Treatment(), ShouldEqual, bodies.TREATMENT_DYNAMIC)
+			So(segment.Treatment(), ShouldEqual, bodies.TREATMENT_STATIC)
+
+			gravity := world.Behaviors()[0].(*behaviors.ConstantAcceleration)
+			So(gravity.Acc, ShouldResemble, geom.Vector{0, 0.0004})
+			So(gravity.AllBodies(), ShouldBeTrue)
+			So(gravity.Targets(), ShouldResemble, world.Bodies())
+			attractor := world.Behaviors()[1].(*behaviors.Attractor)
+			So(attractor.Strength, ShouldEqual, 3)
+			So(attractor.Order, ShouldEqual, 2)
+		})
+
+		Convey("should reject unknown things", func() {
+			_, err := LoadScene(strings.NewReader(`{"version": 2}`))
+			So(err, ShouldEqual, ERROR_SCENE_VERSION)
+			_, err = LoadScene(strings.NewReader(`{"version": 1, "behaviors": [{"type": "magic"}]}`))
+			So(err, ShouldEqual, ERROR_UNKNOWN_BEHAVIOR)
+			_, err = LoadScene(strings.NewReader(`{"version": 1, "bodies": [{"type": "circle", "geometry": {"type": "blob"}}]}`))
+			So(err, ShouldEqual, geometries.ERROR_UNKNOWN_GEOMETRY)
+		})
+
+		Convey("should load and save registered components", func() {
+			type WindOptions struct {
+				Speed float64 `json:"speed"`
+			}
+			RegisterBehavior("test-wind",
+				func() interface{} { return &WindOptions{Speed: 1} },
+				func(options interface{}) (behaviors.Behavior, error) {
+					speed := options.(*WindOptions).Speed
+					return &testWind{behaviors.NewConstantAcceleration(speed, 0).(*behaviors.ConstantAcceleration)}, nil
+				},
+				func(b behaviors.Behavior) (interface{}, bool) {
+					wind, ok := b.(*testWind)
+					if !ok {
+						return nil, false
+					}
+					return &WindOptions{wind.Acc.X}, true
+				})
+			type SquareOptions struct {
+				Size float64 `json:"size"`
+			}
+			RegisterGeometry("test-square",
+				func() interface{} { return &SquareOptions{Size: 1} },
+				func(options interface{}) (geometries.Geometry, error) {
+					size := options.(*SquareOptions).Size
+					return geometries.NewRectangle(size, size), nil
+				}, nil)
+
+			world, err := LoadScene(strings.NewReader(`{
+				"version": 1,
+				"bodies": [{"type": "rectangle", "geometry": {"type": "test-square", "size": 3}}],
+				"behaviors": [{"type": "test-wind", "speed": 0.5, "targets": [0]}]
+			}`))
+			So(err, ShouldBeNil)
+			So(world.Bodies()[0].Geometry(), ShouldResemble, geometries.NewRectangle(3, 3))
+			wind := world.Behaviors()[0].(*testWind)
+			So(wind.Acc.X, ShouldEqual, 0.5)
+			So(wind.Targets(), ShouldResemble, world.Bodies())
+
+			out := &bytes.Buffer{}
+			So(SaveScene(world, out), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, `"type": "test-wind"`)
+			So(out.String(), ShouldContainSubstring, `"speed": 0.5`)
+		})
+	})
+}
+
+type testWind struct {
+	*behaviors.ConstantAcceleration
+}
@@ -0,0 +1,78 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"math"
+)
+
+// SleepOptions put still bodies to sleep. A dynamic body sleeps when it
+// was slower than Vel and AngularVel for Ticks timesteps in a row.
+// Ticks 0 turns it off, which is the default
+type SleepOptions struct {
+	Vel        float64 `json:"vel"`
+	AngularVel float64 `json:"angularVel"`
+	Ticks      uint64  `json:"ticks"`
+}
+
+func (w *world) Sleeping() SleepOptions     { return w.sleeping }
+func (w *world) SetSleeping(o SleepOptions) { w.sleeping = o }
+
+// settle counts the timesteps the bodies are still and puts them to sleep
+func (w *world) settle() {
+	o := w.sleeping
+	if o.Ticks == 0 {
+		return
+	}
+	for _, body := range w.bodies {
+		if body.Treatment() != bodies.TREATMENT_DYNAMIC || body.Asleep() {
+			continue
+		}
+		// the conversions keep the compiler from fusing the operations,
+		// so lockstep worlds fall asleep at the same tick everywhere
+		state := body.State()
+		speed := float64(state.Vel.X*state.Vel.X) + float64(state.Vel.Y*state.Vel.Y)
+		if speed > float64(o.Vel*o.Vel) || math.Abs(state.Angular.Vel) > o.AngularVel {
+			body.SetIdleTicks(0)
+			continue
+		}
+		idle := body.IdleTicks() + 1
+		body.SetIdleTicks(idle)
+		if idle >= o.Ticks {
+			body.Sleep()
+		}
+	}
+}
+
+// recordContacts keeps the bodies that touched at the last timestep.
+// Only the default channel of the collision detection is listened to
+func (w *world) recordContacts(data interface{}) {
+	for _, c := range data.([]behaviors.Collision) {
+		w.contacts[c.BodyA] = append(w.contacts[c.BodyA], c.BodyB)
+		w.contacts[c.BodyB] = append(w.contacts[c.BodyB], c.BodyA)
+	}
+}
+
+func (w *world) forgetContacts() {
+	for body := range w.contacts {
+		delete(w.contacts, body)
+	}
+}
+
+// wakeContacts wakes the bodies that touch the body now
+// or touched it at the last timestep
+func (w *world) wakeContacts(body bodies.Body) {
+	for _, other := range w.contacts[body] {
+		other.Wake()
+	}
+	for _, other := range queryAABB(w.bodies, body.AABB(body.State().Angular.Pos)) {
+		if other != body && len(behaviors.CheckBodies(body, other)) > 0 {
+			other.Wake()
+		}
+	}
+}
+
+// hook makes the body tell the world when its shape changes
+func (w *world) hook(body bodies.Body) {
+	body.SetOnRefit(func() { w.wakeContacts(body) })
+}
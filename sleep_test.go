@@ -0,0 +1,64 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Sleep(t *testing.T) {
+	Convey("Sleep", t, func() {
+		world := NewWorldLockstep()
+		world.Add(
+			behaviors.NewConstantAcceleration(0, 0.0004),
+			behaviors.NewSweepPrune(),
+			behaviors.NewBodyCollisionDetection(),
+			behaviors.NewBodyImpulseResponse(),
+		)
+		ground := bodies.NewRectangle(40, 4)
+		ground.SetPosition(0, 10)
+		ground.SetTreatment(bodies.TREATMENT_STATIC)
+		box := bodies.NewRectangle(4, 4)
+		box.SetPosition(0, 5)
+		box.SetRestitution(0)
+		world.Add(ground, box)
+
+		step := func(n int) {
+			for i := 0; i < n; i++ {
+				world.StepTick()
+			}
+		}
+
+		Convey("should be off by default", func() {
+			step(600)
+			So(box.Asleep(), ShouldBeFalse)
+		})
+
+		Convey("should put still bodies to sleep", func() {
+			world.SetSleeping(SleepOptions{Vel: 0.01, AngularVel: 0.001, Ticks: 60})
+			step(600)
+			So(box.Asleep(), ShouldBeTrue)
+			y := box.State().Pos.Y
+			step(60)
+			So(box.State().Pos.Y, ShouldEqual, y)
+
+			Convey("and wake them when the body under them shrinks", func() {
+				ground.SetScale(0.5)
+				So(box.Asleep(), ShouldBeFalse)
+				step(10)
+				So(box.State().Pos.Y, ShouldBeGreaterThan, y)
+			})
+
+			Convey("and wake them when a body lands on them", func() {
+				ball := bodies.NewCircle(1)
+				ball.SetPosition(0, -5)
+				world.Add(ball)
+				for i := 0; i < 600 && box.Asleep(); i++ {
+					step(1)
+				}
+				So(box.Asleep(), ShouldBeFalse)
+			})
+		})
+	})
+}
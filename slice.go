@@ -0,0 +1,26 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+)
+
+// Slice cuts the body along the line through a and b, removes it and
+// adds the pieces. it returns nil if the body can't be cut there
+func (w *world) Slice(body bodies.Body, a, b geom.Vector) []bodies.Body {
+	slicer, is := body.(bodies.Slicer)
+	if !is {
+		return nil
+	}
+
+	pieces, ok := slicer.Slice(a, b)
+	if !ok {
+		return nil
+	}
+
+	w.Remove(body)
+	for _, piece := range pieces {
+		w.Add(piece)
+	}
+	return pieces
+}
@@ -0,0 +1,50 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test_Slice(t *testing.T) {
+	Convey("Slice", t, func() {
+		world := NewWorldImprovedEuler()
+		box := bodies.NewConvexPolygon([]geom.Vector{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}})
+		circle := bodies.NewCircle(1)
+		world.Add(box, circle)
+
+		Convey("should replace the body with its pieces", func() {
+			pieces := world.Slice(box, geom.Vector{-5, 0}, geom.Vector{5, 0})
+			So(pieces, ShouldHaveLength, 2)
+			So(world.Bodies(), ShouldResemble, []bodies.Body{circle, pieces[0], pieces[1]})
+		})
+
+		Convey("should take the sliced body out of the broadphase", func() {
+			// the broadphase tracks the bodies added after it
+			world := NewWorldImprovedEuler()
+			world.Add(behaviors.NewSweepPrune(), behaviors.NewBodyCollisionDetection())
+			world.Add(box, circle)
+			world.Slice(box, geom.Vector{-5, 0}, geom.Vector{5, 0})
+
+			found := []behaviors.Collision{}
+			callback := func(data interface{}) { found = append(found, data.([]behaviors.Collision)...) }
+			world.On("collisions:detected", &callback)
+			world.StepTick()
+			world.Off("collisions:detected", &callback)
+
+			So(found, ShouldNotBeEmpty)
+			for _, c := range found {
+				So(c.BodyA, ShouldNotEqual, box)
+				So(c.BodyB, ShouldNotEqual, box)
+			}
+		})
+
+		Convey("should leave the bodies it can't cut", func() {
+			So(world.Slice(circle, geom.Vector{-5, 0}, geom.Vector{5, 0}), ShouldBeNil)
+			So(world.Slice(box, geom.Vector{-5, 3}, geom.Vector{5, 3}), ShouldBeNil)
+			So(world.Bodies(), ShouldHaveLength, 2)
+		})
+	})
+}
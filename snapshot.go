@@ -0,0 +1,145 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"time"
+)
+
+// Snapshot is a copy of the simulation state of a world.
+// The bodies and behaviors themselves are shared, not copied
+type Snapshot struct {
+	Time, AnimTime, LastTime time.Time
+	Tick                     uint64
+	LastUID                  int64
+
+	Bodies []bodies.Body
+	States []bodies.BodySnapshot
+
+	// the behaviors in their order and the bodies given to their ApplyTo,
+	// nil for the ones that act on all the bodies
+	Behaviors []behaviors.Behavior
+	Targets   [][]bodies.Body
+
+	// the state of every behaviors.Stateful
+	BehaviorStates map[behaviors.Behavior]interface{}
+}
+
+func (w *world) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Time:           w.time,
+		AnimTime:       w.animTime,
+		LastTime:       w.lastTime,
+		Tick:           w.tick,
+		LastUID:        w.lastUID,
+		Bodies:         append([]bodies.Body{}, w.bodies...),
+		Behaviors:      append([]behaviors.Behavior{}, w.behaviors...),
+		BehaviorStates: map[behaviors.Behavior]interface{}{},
+	}
+	for _, body := range w.bodies {
+		s.States = append(s.States, body.Snapshot())
+	}
+	for _, behavior := range w.behaviors {
+		targets, ok := ownTargets(behavior)
+		if ok {
+			targets = append(make([]bodies.Body, 0, len(targets)), targets...)
+		}
+		s.Targets = append(s.Targets, targets)
+
+		if stateful, is := behavior.(behaviors.Stateful); is {
+			s.BehaviorStates[behavior] = stateful.SaveState()
+		}
+	}
+	return s
+}
+
+// Restore puts the world back to the snapshot. Bodies and behaviors added
+// since then are removed and the removed ones come back, with the usual
+// events. Stepping from there gives the same results as it did after
+// the snapshot
+func (w *world) Restore(s *Snapshot) {
+	w.time, w.animTime, w.lastTime = s.Time, s.AnimTime, s.LastTime
+	w.tick, w.lastUID = s.Tick, s.LastUID
+
+	w.restoreBehaviors(s.Behaviors)
+
+	removed := missingBodies(w.bodies, s.Bodies)
+	added := missingBodies(s.Bodies, w.bodies)
+	w.bodies = append([]bodies.Body{}, s.Bodies...)
+	for i, body := range w.bodies {
+		body.Restore(s.States[i])
+	}
+	w.forgetContacts()
+	for _, body := range removed {
+		body.SetOnRefit(nil)
+		w.Emit("remove:body", body)
+	}
+	for _, body := range added {
+		w.hook(body)
+		w.Emit("add:body", body)
+	}
+
+	// the events above may have changed the state of the behaviors
+	for i, behavior := range w.behaviors {
+		targets := s.Targets[i]
+		if targets != nil {
+			targets = append(make([]bodies.Body, 0, len(targets)), targets...)
+		}
+		behavior.ApplyTo(targets)
+
+		if state, ok := s.BehaviorStates[behavior]; ok {
+			behavior.(behaviors.Stateful).RestoreState(state)
+		}
+	}
+}
+
+// restoreBehaviors brings back the list. the behaviors are connected
+// again in its order, so they listen to the events in the same order
+func (w *world) restoreBehaviors(list []behaviors.Behavior) {
+	same := len(list) == len(w.behaviors)
+	for i := 0; same && i < len(list); i++ {
+		same = list[i] == w.behaviors[i]
+	}
+	if same {
+		return
+	}
+
+	keep := map[behaviors.Behavior]bool{}
+	for _, behavior := range list {
+		keep[behavior] = true
+	}
+	for _, behavior := range append([]behaviors.Behavior{}, w.behaviors...) {
+		if !keep[behavior] {
+			w.RemoveBehavior(behavior)
+		}
+	}
+
+	had := map[behaviors.Behavior]bool{}
+	for _, behavior := range w.behaviors {
+		had[behavior] = true
+		behavior.SetWorld(nil)
+	}
+	w.behaviors = append([]behaviors.Behavior{}, list...)
+	for _, behavior := range w.behaviors {
+		behavior.SetWorld(w)
+	}
+	for _, behavior := range w.behaviors {
+		if !had[behavior] {
+			w.Emit("add:behavior", behavior)
+		}
+	}
+}
+
+// missingBodies returns the bodies of list that are not in other
+func missingBodies(list, other []bodies.Body) (missing []bodies.Body) {
+	in := map[bodies.Body]bool{}
+	for _, body := range other {
+		in[body] = true
+	}
+	for _, body := range list {
+		if !in[body] {
+			missing = append(missing, body)
+		}
+	}
+	return
+}
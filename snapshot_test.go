@@ -0,0 +1,112 @@
+package physics
+
+import (
+	"github.com/oniproject/physics.go/behaviors"
+	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
+	"github.com/oniproject/physics.go/geometries"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func Test_Snapshot(t *testing.T) {
+	Convey("Snapshot", t, func() {
+		world := NewWorldImprovedEuler()
+		world.Add(
+			behaviors.NewConstantAcceleration(0, 0.0004),
+			behaviors.NewSweepPrune(),
+			behaviors.NewBodyCollisionDetection(),
+			behaviors.NewBodyImpulseResponse(),
+		)
+
+		ground := bodies.NewRectangle(100, 10)
+		ground.SetPosition(0, 20)
+		ground.SetTreatment(bodies.TREATMENT_STATIC)
+		box := bodies.NewRectangle(4, 4)
+		box.SetPosition(0, 12)
+		box.State().Angular.Vel = 0.001
+		world.Add(ground, box)
+
+		now := time.Unix(0, 0)
+		step := func(n int) (states []bodies.BodyState) {
+			for i := 0; i < n; i++ {
+				now = now.Add(world.TimeStep())
+				world.Step(now)
+				states = append(states, *box.State())
+				states[len(states)-1].Old = nil
+			}
+			return
+		}
+
+		step(10)
+		snapshot := world.Snapshot()
+		savedNow := now
+		first := step(50)
+
+		Convey("should step the same way after a restore", func() {
+			extra := bodies.NewCircle(1)
+			world.Add(extra)
+			box.SetMass(5)
+
+			world.Restore(snapshot)
+			now = savedNow
+			So(world.Bodies(), ShouldHaveLength, 2)
+			So(step(50), ShouldResemble, first)
+		})
+
+		Convey("should undo the changes to shapes, bodies and behaviors", func() {
+			world := NewWorldImprovedEuler()
+			gravity := behaviors.NewConstantAcceleration(0, 0.0004)
+			world.Add(
+				gravity,
+				behaviors.NewSweepPrune(),
+				behaviors.NewBodyCollisionDetection(),
+				behaviors.NewBodyImpulseResponse(),
+			)
+			ground := bodies.NewRectangle(100, 10)
+			ground.SetPosition(0, 20)
+			ground.SetTreatment(bodies.TREATMENT_STATIC)
+			box := bodies.NewRectangle(4, 4)
+			box.SetPosition(-5, 12)
+			poly := bodies.NewConvexPolygon([]geom.Vector{{-2, -1}, {2, -1}, {2, 1}, {-2, 1}})
+			poly.SetPosition(5, 12)
+			world.Add(ground, box, poly)
+
+			now := time.Unix(0, 0)
+			checksums := func(n int) (sums []uint64) {
+				for i := 0; i < n; i++ {
+					now = now.Add(world.TimeStep())
+					world.Step(now)
+					sums = append(sums, world.Checksum())
+				}
+				return
+			}
+
+			checksums(10)
+			snapshot := world.Snapshot()
+			savedNow := now
+			vertices := append([]geom.Vector{}, poly.Geometry().(*geometries.ConvexPolygon).Vertices...)
+			first := checksums(50)
+
+			box.SetScale(1.2)
+			So(poly.(*bodies.ConvexPolygon).SetVertices([]geom.Vector{{-1, -1}, {1, -1}, {0, 2}}), ShouldBeNil)
+			world.RemoveBody(ground)
+			world.Add(bodies.NewCircle(1))
+			world.RemoveBehavior(gravity)
+			newton := behaviors.NewNewtonian(1)
+			newton.ApplyTo([]bodies.Body{box})
+			world.Add(newton)
+			checksums(5)
+
+			world.Restore(snapshot)
+			now = savedNow
+			So(world.Bodies(), ShouldResemble, []bodies.Body{ground, box, poly})
+			So(world.Behaviors(), ShouldHaveLength, 4)
+			So(world.Behaviors()[0], ShouldEqual, gravity)
+			So(box.Scale(), ShouldEqual, 1)
+			So(poly.Geometry().(*geometries.ConvexPolygon).Vertices, ShouldResemble, vertices)
+			So(checksums(50), ShouldResemble, first)
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+)
+
+var ERROR_REGISTERED = errors.New("Error: The name is already registered.")
+var ERROR_BAD_OPTIONS = errors.New("Error: The options are of the wrong type.")
+var ERROR_BAD_FACTORY = errors.New("Error: The defaults and the factory must not be nil.")
+var ERROR_UNDESCRIBED = errors.New("Error: No registered type describes the component.")
+
+// Factory makes a component from a pointer to its options struct
+type Factory func(options interface{}) (interface{}, error)
+
+// Describer returns the options that make the component again,
+// ok is false when the component is not of its type
+type Describer func(component interface{}) (options interface{}, ok bool)
+
+// Registry keeps factories by name. defaults returns a pointer
+// to a new options struct, which is what the factory gets
+type Registry struct {
+	unknown error
+	entries map[string]registryEntry
+	names   []string // sorted
+}
+
+type registryEntry struct {
+	defaults func() interface{}
+	factory  Factory
+	describe Describer
+}
+
+// NewRegistry returns an empty registry, unknown is the error for missing names
+func NewRegistry(unknown error) *Registry {
+	return &Registry{unknown: unknown, entries: map[string]registryEntry{}}
+}
+
+// Register adds a factory. describe can be nil,
+// then the components of this type can't be encoded
+func (r *Registry) Register(name string, defaults func() interface{}, factory Factory, describe Describer) error {
+	if defaults == nil || factory == nil {
+		return ERROR_BAD_FACTORY
+	}
+	if _, ok := r.entries[name]; ok {
+		return ERROR_REGISTERED
+	}
+	r.entries[name] = registryEntry{defaults, factory, describe}
+	r.names = append(r.names, name)
+	sort.Strings(r.names)
+	return nil
+}
+
+// Options returns the default options of a type to fill in
+func (r *Registry) Options(name string) (interface{}, error) {
+	entry, ok := r.entries[name]
+	if !ok {
+		return nil, r.unknown
+	}
+	return entry.defaults(), nil
+}
+
+// Create accepts nil for the defaults, the options struct or a pointer to it
+func (r *Registry) Create(name string, options interface{}) (interface{}, error) {
+	entry, ok := r.entries[name]
+	if !ok {
+		return nil, r.unknown
+	}
+
+	defaults := entry.defaults()
+	want := reflect.TypeOf(defaults)
+	switch {
+	case options == nil:
+		options = defaults
+	case reflect.TypeOf(options) == want:
+	case want.Kind() == reflect.Ptr && reflect.TypeOf(options) == want.Elem():
+		ptr := reflect.New(want.Elem())
+		ptr.Elem().Set(reflect.ValueOf(options))
+		options = ptr.Interface()
+	default:
+		return nil, ERROR_BAD_OPTIONS
+	}
+	return entry.factory(options)
+}
+
+// Describe finds the type of the component and its options.
+// the names are tried in order
+func (r *Registry) Describe(component interface{}) (name string, options interface{}, err error) {
+	for _, name := range r.names {
+		if describe := r.entries[name].describe; describe != nil {
+			if options, ok := describe(component); ok {
+				return name, options, nil
+			}
+		}
+	}
+	return "", nil, ERROR_UNDESCRIBED
+}
+
+func (r *Registry) Names() []string { return append([]string{}, r.names...) }
+
+// Encode turns the component into its type name and the fields of its options
+func (r *Registry) Encode(component interface{}) (d ComponentData, err error) {
+	name, options, err := r.Describe(component)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		return
+	}
+	d.Type = name
+	err = json.Unmarshal(data, &d.Fields)
+	return
+}
+
+// Decode makes the component. the missing fields keep their defaults
+func (r *Registry) Decode(d ComponentData) (interface{}, error) {
+	options, err := r.Options(d.Type)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Fields) != 0 {
+		data, err := json.Marshal(d.Fields)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, options); err != nil {
+			return nil, err
+		}
+	}
+	return r.Create(d.Type, options)
+}
+
+// ComponentData is the JSON form of a registered component:
+// an object with the type name next to the fields of its options
+type ComponentData struct {
+	Type   string
+	Fields map[string]json.RawMessage
+}
+
+func (d ComponentData) MarshalJSON() ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	for k, v := range d.Fields {
+		fields[k] = v
+	}
+	name, err := json.Marshal(d.Type)
+	if err != nil {
+		return nil, err
+	}
+	fields["type"] = name
+	return json.Marshal(fields)
+}
+
+func (d *ComponentData) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	d.Type = ""
+	if name, ok := fields["type"]; ok {
+		if err := json.Unmarshal(name, &d.Type); err != nil {
+			return err
+		}
+		delete(fields, "type")
+	}
+	d.Fields = fields
+	return nil
+}
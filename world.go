@@ -3,6 +3,7 @@ package physics
 import (
 	"github.com/oniproject/physics.go/behaviors"
 	"github.com/oniproject/physics.go/bodies"
+	"github.com/oniproject/physics.go/geom"
 	"github.com/oniproject/physics.go/integrators"
 	"github.com/oniproject/physics.go/renderers"
 	"github.com/oniproject/physics.go/util"
@@ -31,6 +32,17 @@ type World interface {
 	//Find(query Query) []interface{}
 	//FindOne(query Query) interface{}
 
+	QueryPoint(pt geom.Vector) []bodies.Body
+	QueryAABB(box geom.AABB) []bodies.Body
+	RayCast(from, to geom.Vector) (RayCastHit, bool)
+
+	Morph(body bodies.Body, change func())
+	Slice(body bodies.Body, a, b geom.Vector) []bodies.Body
+	Carve(crater []geom.Vector) ([]bodies.Body, error)
+
+	Snapshot() *Snapshot
+	Restore(*Snapshot)
+
 	Behaviors() []behaviors.Behavior
 	Bodies() []bodies.Body
 
@@ -46,8 +58,13 @@ type World interface {
 	Render()
 
 	Step(now time.Time)
+	StepTick()
+	Tick() uint64
+	Checksum() uint64
 	TimeStep() time.Duration
 	SetTimeStep(now time.Duration)
+	Sleeping() SleepOptions
+	SetSleeping(SleepOptions)
 
 	//Warp()
 
@@ -59,6 +76,7 @@ type World interface {
 }*/
 func NewWorldImprovedEuler() (w World) {
 	w = &world{
+		kind:   "improved-euler",
 		maxIPF: 16,
 		PubSub: util.NewPubSub(),
 
@@ -66,11 +84,13 @@ func NewWorldImprovedEuler() (w World) {
 	}
 	w.SetIntegrator(integrators.NewImprovedEuler())
 	w.SetTimeStep(time.Second / 120)
+	w.(*world).listen()
 	log.Println("init world", w)
 	return
 }
 
 type world struct {
+	kind   string // the constructor, for the replays
 	maxIPF int
 
 	meta renderers.Meta
@@ -91,9 +111,24 @@ type world struct {
 	dt      time.Duration
 	maxJump time.Duration
 
+	// the last UID given to a body and the number of timesteps taken
+	lastUID int64
+	tick    uint64
+
+	sleeping  SleepOptions
+	contacts  map[bodies.Body][]bodies.Body
+	contactsC func(interface{})
+
 	util.PubSub
 }
 
+// listen connects the world to its own events
+func (w *world) listen() {
+	w.contacts = map[bodies.Body][]bodies.Body{}
+	w.contactsC = w.recordContacts
+	w.On("collisions:detected", &w.contactsC)
+}
+
 func (w *world) Integrator() integrators.Integrator { return w.integrator }
 func (w *world) SetIntegrator(integrator integrators.Integrator) {
 	if integrator == w.integrator {
@@ -151,6 +186,7 @@ func (w *world) RemoveBehavior(behavior behaviors.Behavior) {
 	for i, b := range w.behaviors {
 		if b == behavior {
 			w.behaviors = append(w.behaviors[:i], w.behaviors[i+1:]...)
+			behavior.SetWorld(nil)
 			w.Emit("remove:behavior", behavior)
 			return
 		}
@@ -160,8 +196,13 @@ func (w *world) RemoveBehavior(behavior behaviors.Behavior) {
 func (w *world) Bodies() []bodies.Body { return w.bodies }
 func (w *world) AddBody(body bodies.Body) {
 	w.RemoveBody(body)
+	// bodies are numbered in the order they are added,
+	// so the UIDs are the same wherever the world is built
+	w.lastUID++
+	body.SetUID(w.lastUID)
 	body.Recalc()
 	//body.SetWorld(w)
+	w.hook(body)
 	w.bodies = append(w.bodies, body)
 	w.Emit("add:body", body)
 }
@@ -169,6 +210,7 @@ func (w *world) RemoveBody(body bodies.Body) {
 	for i, b := range w.bodies {
 		if b == body {
 			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			body.SetOnRefit(nil)
 			w.Emit("remove:body", body)
 			return
 		}
@@ -181,10 +223,14 @@ type IntegrateEvent struct {
 }
 
 func (w *world) Itertate(dt time.Duration) {
+	w.forgetContacts()
 	w.integrator.IntegrateVelocities(w.bodies, dt)
 	w.Emit("integrate:velocities", IntegrateEvent{w.bodies, dt})
 	w.integrator.IntegratePositions(w.bodies, dt)
 	w.Emit("integrate:positions", IntegrateEvent{w.bodies, dt})
+	w.settle()
+	// once per timestep, a Step may take several
+	w.Emit("tick", w.tick)
 }
 
 func (w *world) Render() {
@@ -247,6 +293,7 @@ func (w *world) Step(now time.Time) {
 
 	//for w.time <= target {
 	for w.time.Sub(target) <= 0 {
+		w.tick++
 		w.time = w.time.Add(w.dt)
 		w.animTime = w.animTime.Add(animDt)
 		w.Itertate(w.dt * 1000)